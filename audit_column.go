@@ -0,0 +1,25 @@
+package gorep
+
+// AuditColumnKind is kind of audit column, set by generated repository on insert and update
+type AuditColumnKind string
+
+const (
+	// AuditColumnCreatedAt is set to current time on insert, "created_at" if no patterns are set
+	AuditColumnCreatedAt AuditColumnKind = "created_at"
+	// AuditColumnUpdatedAt is set to current time on insert and update, "updated_at" if no patterns are set
+	AuditColumnUpdatedAt AuditColumnKind = "updated_at"
+	// AuditColumnCreatedBy is set to actor of context on insert, "created_by" if no patterns are set
+	AuditColumnCreatedBy AuditColumnKind = "created_by"
+	// AuditColumnUpdatedBy is set to actor of context on insert and update, "updated_by" if no patterns are set
+	AuditColumnUpdatedBy AuditColumnKind = "updated_by"
+)
+
+// IsTimestamp returns true for audit columns, set to current time
+func (k AuditColumnKind) IsTimestamp() bool {
+	return k == AuditColumnCreatedAt || k == AuditColumnUpdatedAt
+}
+
+// IsUpdated returns true for audit columns, set on update
+func (k AuditColumnKind) IsUpdated() bool {
+	return k == AuditColumnUpdatedAt || k == AuditColumnUpdatedBy
+}
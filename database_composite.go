@@ -0,0 +1,21 @@
+package gorep
+
+// DatabaseComposite is Go structure, generated for PostgreSQL composite type, passed to DTO template
+type DatabaseComposite struct {
+	// Name is Go type name, composite type name in pascal case
+	Name string
+	// Fields are composite type attributes in attributes order
+	Fields []DatabaseCompositeField
+	// HasTime is true if any of fields is sql.NullTime
+	HasTime bool
+}
+
+// DatabaseCompositeField is composite type attribute
+type DatabaseCompositeField struct {
+	// Name is attribute name
+	Name string
+	// Type is Go nullable type of structure field, as composite type attributes could always contain NULL values
+	Type string
+	// IsTime is true if field is sql.NullTime, which could not be scanned from row literal text by itself
+	IsTime bool
+}
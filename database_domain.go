@@ -0,0 +1,9 @@
+package gorep
+
+// DatabaseDomain is named Go type, generated for PostgreSQL domain, passed to DTO template
+type DatabaseDomain struct {
+	// Name is Go type name, domain name in pascal case
+	Name string
+	// Type is Go type of domain base type
+	Type string
+}
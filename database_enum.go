@@ -0,0 +1,17 @@
+package gorep
+
+// DatabaseEnum is string type with constants, generated for PostgreSQL enum or CHECK constraint, passed to DTO template
+type DatabaseEnum struct {
+	// Name is Go type name
+	Name string
+	// Values are enum constants in enum order
+	Values []DatabaseEnumValue
+}
+
+// DatabaseEnumValue is enum constant
+type DatabaseEnumValue struct {
+	// Name is Go constant name, enum type name with label in pascal case
+	Name string
+	// Label is enum value in database
+	Label string
+}
@@ -1,6 +1,21 @@
 package gorep
 
-type databaseField struct {
+// DatabaseField is table column, passed to DTO template
+type DatabaseField struct {
+	// Name is column name
 	Name string
+	// Type is Go type of DTO field
 	Type string
+	// DatabaseType is database type name of column, base type name for columns of domain types
+	DatabaseType string
+	// DomainName is PostgreSQL domain name of column type, empty if column type is not domain
+	DomainName string
+	// IsNullable is true if column could contain NULL values
+	IsNullable bool
+	// IsPrimaryKey is true if column is part of table primary key
+	IsPrimaryKey bool
+	// IsGenerated is true if column value is generated by database: serial, identity or generated column
+	IsGenerated bool
+	// Tags are DTO field struct tags without backquotes, `db:"name"` tag is always first
+	Tags string
 }
@@ -0,0 +1,13 @@
+package gorep
+
+// DatabaseForeignKey is foreign key constraint of table, passed to repository template
+type DatabaseForeignKey struct {
+	// Name is constraint name
+	Name string
+	// Columns are constrained columns in constraint order
+	Columns []string
+	// ReferencedTable is referenced table name without schema
+	ReferencedTable string
+	// ReferencedColumns are referenced columns in constraint order
+	ReferencedColumns []string
+}
@@ -0,0 +1,11 @@
+package gorep
+
+// DatabaseIndex is index of table, passed to repository template
+type DatabaseIndex struct {
+	// Name is index name
+	Name string
+	// Columns are index key columns in index order
+	Columns []string
+	// IsUnique is true for unique indexes, including indexes of primary key and unique constraints
+	IsUnique bool
+}
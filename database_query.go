@@ -0,0 +1,15 @@
+package gorep
+
+// DatabaseQuery is named query of SQL file, described by database
+type DatabaseQuery struct {
+	// Name is query name, used as function name
+	Name string
+	// Command defines how query result is returned
+	Command QueryCommand
+	// Query is SQL query with "$1" placeholders
+	Query string
+	// Parameters are query parameters in placeholders order, named "arg1", "arg2" and so on
+	Parameters []DatabaseField
+	// Columns are result columns in query order
+	Columns []DatabaseField
+}
@@ -1,8 +1,19 @@
 package gorep
 
-import "github.com/jmoiron/sqlx"
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
 
 type Database interface {
 	sqlx.Queryer
 	sqlx.Execer
+	sqlx.QueryerContext
+	sqlx.ExecerContext
+}
+
+// ConnectionDatabase is database, providing dedicated connections, which keep session state between queries
+type ConnectionDatabase interface {
+	Connx(ctx context.Context) (*sqlx.Conn, error)
 }
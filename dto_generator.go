@@ -2,9 +2,13 @@ package gorep
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	_ "embed"
 	"errors"
 	"fmt"
+	"io/fs"
+	"regexp"
 	"sort"
 	"strings"
 	"text/template"
@@ -36,26 +40,52 @@ const (
 	databaseFieldTypeSmallint         = "smallint"
 	databaseFieldTypeText             = "text"
 	databaseFieldTypeTimestamp        = "timestamp"
+	databaseFieldTypeTimestamptz      = "timestamptz"
 	databaseFieldTypeTinyint          = "tinyint"
 	databaseFieldTypeUnsignedBigInt   = "unsigned big int"
 	databaseFieldTypeVarchar          = "varchar"
 	databaseFieldTypeVaryingCharacter = "varying character"
 )
 
+var (
+	checkEnumPattern = regexp.MustCompile(
+		`^CHECK \(+"?\w+"?\)*(::[\w ]+)? = ANY \(+ARRAY\[('(?:[^']|'')*'(::[\w ]+)?(, )?)+\]\)*(::[\w \[\]]+)?\)+$`,
+	)
+	stringLiteralPattern = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
 //go:embed dto.template
 var templateFile string
 
 type DtoGenerator struct {
-	database    Database
-	templateDTO string
+	database           Database
+	templateDTO        string
+	templateFileSystem fs.FS
+	templatePatterns   []string
+	templateFunctions  template.FuncMap
+	tags               []DtoTag
+	withEnums          bool
+	withCheckEnums     bool
+	withDomainTypes    bool
+	withCompositeTypes bool
 }
 
-func NewDtoGenerator(database Database) *DtoGenerator {
-	return &DtoGenerator{database: database, templateDTO: templateFile}
+func NewDtoGenerator(database Database, options ...DtoGeneratorOption) *DtoGenerator {
+	generator := &DtoGenerator{database: database, templateDTO: templateFile}
+	for _, option := range options {
+		option(generator)
+	}
+
+	return generator
 }
 
 // Generate generates DTO for dtoPath as file content string
 func (g *DtoGenerator) Generate(packageName string, tableName string) (string, error) {
+	return g.GenerateContext(context.Background(), packageName, tableName)
+}
+
+// GenerateContext generates DTO for dtoPath as file content string. Database queries are cancelled with context.
+func (g *DtoGenerator) GenerateContext(ctx context.Context, packageName string, tableName string) (string, error) {
 	if len(packageName) == 0 {
 		return "", errors.New("package name must not be empty")
 	}
@@ -64,19 +94,18 @@ func (g *DtoGenerator) Generate(packageName string, tableName string) (string, e
 		return "", errors.New("table name must not be empty")
 	}
 
-	templator, err := template.New("dto.template").
-		Funcs(
-			template.FuncMap{
-				"Uppercase": StringCaseConverter{}.SnakeCaseToCamelCase,
-			},
-		).
-		Parse(g.templateDTO)
-
+	templator, err := parseTemplate(
+		"dto.template",
+		g.templateDTO,
+		g.templateFileSystem,
+		g.templatePatterns,
+		mergeTemplateFunctions(g.templateFunctions),
+	)
 	if err != nil {
 		return "", err
 	}
 
-	fields, err := g.fetchFields(tableName)
+	fields, relationKind, err := g.fetchFields(ctx, tableName)
 	if err != nil {
 		return "", err
 	}
@@ -91,20 +120,48 @@ func (g *DtoGenerator) Generate(packageName string, tableName string) (string, e
 		},
 	)
 
+	enums, err := g.createEnums(ctx, tableName, fields)
+	if err != nil {
+		return "", err
+	}
+
+	composites, err := g.createComposites(ctx, tableName, fields)
+	if err != nil {
+		return "", err
+	}
+
+	domains := g.createDomains(fields)
+
+	for i, field := range fields {
+		fields[i].Tags, err = g.buildTags(field)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	imports := g.createImports(fields)
+	if len(enums) > 0 {
+		imports = g.appendImports(imports, "database/sql/driver", "fmt")
+	}
+
+	for _, composite := range composites {
+		imports = g.appendImports(imports, "database/sql", "database/sql/driver", "fmt", "strings")
+		if composite.HasTime {
+			imports = g.appendImports(imports, "time")
+		}
+	}
 
 	_, tableName = g.parseSchemaAndTableName(tableName)
 
-	data := struct {
-		PackageName string
-		TableName   string
-		Fields      []databaseField
-		Imports     []string
-	}{
-		PackageName: packageName,
-		TableName:   tableName,
-		Fields:      fields,
-		Imports:     imports,
+	data := DtoTemplateData{
+		PackageName:  packageName,
+		TableName:    tableName,
+		RelationKind: relationKind,
+		Fields:       fields,
+		Imports:      imports,
+		Enums:        enums,
+		Domains:      domains,
+		Composites:   composites,
 	}
 
 	var buffer bytes.Buffer
@@ -116,28 +173,63 @@ func (g *DtoGenerator) Generate(packageName string, tableName string) (string, e
 	return buffer.String(), nil
 }
 
-func (g *DtoGenerator) fetchFields(tableName string) ([]databaseField, error) {
+// fetchFields returns relation columns and relation kind. Relation kind is empty if relation was not found.
+func (g *DtoGenerator) fetchFields(ctx context.Context, tableName string) ([]DatabaseField, RelationKind, error) {
 	schema, tableName := g.parseSchemaAndTableName(tableName)
 
-	rows, err := g.database.Query(
-		fmt.Sprintf(
-			"SELECT column_name, udt_name, is_nullable FROM information_schema.columns WHERE table_schema = '%s' AND table_name = '%s'",
+	relationKind, err := g.fetchRelationKind(ctx, schema, tableName)
+	if err != nil || relationKind == "" {
+		return nil, relationKind, err
+	}
+
+	var rows *sql.Rows
+	if relationKind == RelationKindMaterializedView {
+		// materialized views are not listed in information_schema.columns
+		rows, err = g.database.QueryContext(
+			ctx,
+			`SELECT pg_attribute.attname,
+				COALESCE(base_type.typname, pg_type.typname),
+				CASE WHEN pg_attribute.attnotnull THEN 'NO' ELSE 'YES' END,
+				CASE WHEN pg_type.typtype = 'd' THEN pg_type.typname ELSE '' END,
+				false
+			FROM pg_attribute
+			JOIN pg_class ON pg_class.oid = pg_attribute.attrelid
+			JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+			JOIN pg_type ON pg_type.oid = pg_attribute.atttypid
+			LEFT JOIN pg_type base_type ON base_type.oid = pg_type.typbasetype
+			WHERE pg_namespace.nspname = $1
+				AND pg_class.relname = $2
+				AND pg_attribute.attnum > 0
+				AND NOT pg_attribute.attisdropped`,
 			schema,
 			tableName,
-		),
-	)
+		)
+	} else {
+		// udt_name of domain column is domain base type name, so domain columns are mapped as base types
+		rows, err = g.database.QueryContext(
+			ctx,
+			`SELECT column_name, udt_name, is_nullable, COALESCE(domain_name, ''),
+				COALESCE(column_default, '') LIKE 'nextval(%' OR is_identity = 'YES' OR is_generated = 'ALWAYS'
+			FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2`,
+			schema,
+			tableName,
+		)
+	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	defer rows.Close()
 
-	var fields []databaseField
+	var fields []DatabaseField
 	for rows.Next() {
 		var columnName string
 		var columnType string
 		var isNullableData string
-		err = rows.Scan(&columnName, &columnType, &isNullableData)
+		var domainName string
+		var isGenerated bool
+		err = rows.Scan(&columnName, &columnType, &isNullableData, &domainName, &isGenerated)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		var isNullable bool
@@ -154,18 +246,505 @@ func (g *DtoGenerator) fetchFields(tableName string) ([]databaseField, error) {
 		}
 
 		fields = append(
-			fields, databaseField{
-				Name: columnName,
-				Type: databaseType,
+			fields, DatabaseField{
+				Name:         columnName,
+				Type:         databaseType,
+				DatabaseType: databaseTypeName,
+				DomainName:   domainName,
+				IsNullable:   isNullable,
+				IsGenerated:  isGenerated,
 			},
 		)
 	}
 	err = rows.Err()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(fields) == 0 {
+		return fields, relationKind, nil
+	}
+
+	primaryKeyColumns, err := g.fetchPrimaryKeyColumns(ctx, schema, tableName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i, field := range fields {
+		_, fields[i].IsPrimaryKey = primaryKeyColumns[field.Name]
+	}
+
+	return fields, relationKind, nil
+}
+
+// fetchRelationKind returns relation kind by pg_class.relkind, or empty kind if relation was not found
+func (g *DtoGenerator) fetchRelationKind(ctx context.Context, schema string, tableName string) (RelationKind, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT pg_class.relkind
+		FROM pg_class
+		JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+		WHERE pg_namespace.nspname = $1 AND pg_class.relname = $2`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var relationKind RelationKind
+	for rows.Next() {
+		var relationKindCode string
+		err = rows.Scan(&relationKindCode)
+		if err != nil {
+			return "", err
+		}
+
+		switch relationKindCode {
+		case "r", "p", "f":
+			relationKind = RelationKindTable
+		case "v":
+			relationKind = RelationKindView
+		case "m":
+			relationKind = RelationKindMaterializedView
+		}
+	}
+
+	return relationKind, rows.Err()
+}
+
+func (g *DtoGenerator) fetchPrimaryKeyColumns(ctx context.Context, schema string, tableName string) (map[string]struct{}, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT key_column_usage.column_name
+		FROM information_schema.table_constraints
+		JOIN information_schema.key_column_usage
+			ON key_column_usage.constraint_schema = table_constraints.constraint_schema
+			AND key_column_usage.constraint_name = table_constraints.constraint_name
+			AND key_column_usage.table_name = table_constraints.table_name
+		WHERE table_constraints.constraint_type = 'PRIMARY KEY'
+			AND table_constraints.table_schema = $1
+			AND table_constraints.table_name = $2`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	primaryKeyColumns := make(map[string]struct{})
+	for rows.Next() {
+		var columnName string
+		err = rows.Scan(&columnName)
+		if err != nil {
+			return nil, err
+		}
+
+		primaryKeyColumns[columnName] = struct{}{}
+	}
+
+	return primaryKeyColumns, rows.Err()
+}
+
+// createEnums creates enum types for enum columns and replaces types of these fields with enum types
+func (g *DtoGenerator) createEnums(ctx context.Context, tableName string, fields []DatabaseField) ([]DatabaseEnum, error) {
+	if !g.withEnums && !g.withCheckEnums {
+		return nil, nil
+	}
+
+	schema, tableName := g.parseSchemaAndTableName(tableName)
+
+	columnEnums := make(map[string]DatabaseEnum)
+	if g.withCheckEnums {
+		checkEnums, err := g.fetchCheckEnums(ctx, schema, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range fields {
+			enum, ok := checkEnums[field.Name]
+			if ok && (field.Type == "string" || field.Type == "sql.NullString") {
+				columnEnums[field.Name] = enum
+			}
+		}
+	}
+
+	if g.withEnums {
+		typeEnums, err := g.fetchTypeEnums(ctx, schema, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		for columnName, enum := range typeEnums {
+			columnEnums[columnName] = enum
+		}
+	}
+
+	enumsMap := make(map[string]DatabaseEnum)
+	for i, field := range fields {
+		enum, ok := columnEnums[field.Name]
+		if !ok {
+			continue
+		}
+
+		fields[i].Type = enum.Name
+		if field.IsNullable {
+			fields[i].Type = "*" + enum.Name
+		}
+
+		enumsMap[enum.Name] = enum
+	}
+
+	enums := make([]DatabaseEnum, 0, len(enumsMap))
+	for _, enum := range enumsMap {
+		enums = append(enums, enum)
+	}
+
+	sort.Slice(
+		enums, func(i, j int) bool {
+			return enums[i].Name < enums[j].Name
+		},
+	)
+
+	// labels, which differ only by separators or case, such as "in-progress" and "in_progress", get the same name
+	labels := make(map[string]string)
+	for _, enum := range enums {
+		for _, value := range enum.Values {
+			if label, ok := labels[value.Name]; ok {
+				return nil, fmt.Errorf(
+					"enum labels \"%s\" and \"%s\" have the same constant name %s",
+					label,
+					value.Label,
+					value.Name,
+				)
+			}
+
+			labels[value.Name] = value.Label
+		}
+	}
+
+	return enums, nil
+}
+
+// fetchTypeEnums returns enums of table columns with PostgreSQL enum types by column names
+func (g *DtoGenerator) fetchTypeEnums(ctx context.Context, schema string, tableName string) (map[string]DatabaseEnum, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT columns.column_name, pg_type.typname, pg_enum.enumlabel
+		FROM information_schema.columns
+		JOIN pg_namespace ON pg_namespace.nspname = columns.udt_schema
+		JOIN pg_type ON pg_type.typnamespace = pg_namespace.oid AND pg_type.typname = columns.udt_name
+		JOIN pg_enum ON pg_enum.enumtypid = pg_type.oid
+		WHERE columns.table_schema = $1 AND columns.table_name = $2
+		ORDER BY columns.column_name, pg_enum.enumsortorder`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enums := make(map[string]DatabaseEnum)
+	for rows.Next() {
+		var columnName string
+		var typeName string
+		var label string
+		err = rows.Scan(&columnName, &typeName, &label)
+		if err != nil {
+			return nil, err
+		}
+
+		enum := enums[columnName]
+		enum.Name = StringCaseConverter{}.ToPascalCase(typeName)
+		enum.Values = append(enum.Values, g.createEnumValue(enum.Name, label))
+		enums[columnName] = enum
+	}
+
+	return enums, rows.Err()
+}
+
+// fetchCheckEnums returns enums of table columns with "CHECK (column IN (...))" constraints by column names
+func (g *DtoGenerator) fetchCheckEnums(ctx context.Context, schema string, tableName string) (map[string]DatabaseEnum, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT pg_attribute.attname, pg_get_constraintdef(pg_constraint.oid)
+		FROM pg_constraint
+		JOIN pg_class ON pg_class.oid = pg_constraint.conrelid
+		JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+		JOIN pg_attribute ON pg_attribute.attrelid = pg_class.oid AND pg_attribute.attnum = pg_constraint.conkey[1]
+		WHERE pg_constraint.contype = 'c'
+			AND array_length(pg_constraint.conkey, 1) = 1
+			AND pg_namespace.nspname = $1
+			AND pg_class.relname = $2
+		ORDER BY pg_attribute.attname, pg_constraint.conname`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enums := make(map[string]DatabaseEnum)
+	for rows.Next() {
+		var columnName string
+		var constraintDefinition string
+		err = rows.Scan(&columnName, &constraintDefinition)
+		if err != nil {
+			return nil, err
+		}
+
+		labels, ok := g.parseCheckEnumLabels(constraintDefinition)
+		if !ok {
+			continue
+		}
+
+		if _, ok := enums[columnName]; ok {
+			continue
+		}
+
+		enum := DatabaseEnum{
+			Name: StringCaseConverter{}.ToPascalCase(tableName) + StringCaseConverter{}.ToPascalCase(columnName),
+		}
+		for _, label := range labels {
+			enum.Values = append(enum.Values, g.createEnumValue(enum.Name, label))
+		}
+
+		enums[columnName] = enum
+	}
+
+	return enums, rows.Err()
+}
+
+// parseCheckEnumLabels returns string literals of "CHECK (column IN (...))" constraint definition, which is stored by
+// PostgreSQL as "CHECK ((column = ANY (ARRAY['a'::text, 'b'::text])))". Other constraints are not enums.
+func (*DtoGenerator) parseCheckEnumLabels(constraintDefinition string) ([]string, bool) {
+	if !checkEnumPattern.MatchString(constraintDefinition) {
+		return nil, false
+	}
+
+	var labels []string
+	for _, match := range stringLiteralPattern.FindAllStringSubmatch(constraintDefinition, -1) {
+		labels = append(labels, strings.ReplaceAll(match[1], "''", "'"))
+	}
+
+	return labels, len(labels) > 0
+}
+
+func (*DtoGenerator) createEnumValue(enumName string, label string) DatabaseEnumValue {
+	labelName := StringCaseConverter{}.ToPascalCase(label)
+	if labelName == "" {
+		labelName = "Empty"
+	}
+
+	return DatabaseEnumValue{
+		Name:  goIdentifier(enumName + labelName),
+		Label: label,
+	}
+}
+
+// createComposites creates structures for columns of composite types and replaces types of these fields with
+// structure types
+func (g *DtoGenerator) createComposites(ctx context.Context, tableName string, fields []DatabaseField) ([]DatabaseComposite, error) {
+	if !g.withCompositeTypes {
+		return nil, nil
+	}
+
+	schema, tableName := g.parseSchemaAndTableName(tableName)
+
+	columnComposites, err := g.fetchComposites(ctx, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	compositesMap := make(map[string]DatabaseComposite)
+	for i, field := range fields {
+		composite, ok := columnComposites[field.Name]
+		if !ok {
+			continue
+		}
+
+		fields[i].Type = composite.Name
+		if field.IsNullable {
+			fields[i].Type = "*" + composite.Name
+		}
+
+		compositesMap[composite.Name] = composite
+	}
+
+	composites := make([]DatabaseComposite, 0, len(compositesMap))
+	for _, composite := range compositesMap {
+		composites = append(composites, composite)
+	}
+
+	sort.Slice(
+		composites, func(i, j int) bool {
+			return composites[i].Name < composites[j].Name
+		},
+	)
+
+	return composites, nil
+}
+
+// fetchComposites returns composite types of table columns by column names
+func (g *DtoGenerator) fetchComposites(ctx context.Context, schema string, tableName string) (map[string]DatabaseComposite, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT columns.column_name, attributes.udt_name, attributes.attribute_name, attributes.attribute_udt_name
+		FROM information_schema.columns
+		JOIN information_schema.attributes
+			ON attributes.udt_schema = columns.udt_schema AND attributes.udt_name = columns.udt_name
+		WHERE columns.table_schema = $1 AND columns.table_name = $2
+		ORDER BY columns.column_name, attributes.ordinal_position`,
+		schema,
+		tableName,
+	)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	composites := make(map[string]DatabaseComposite)
+	for rows.Next() {
+		var columnName string
+		var typeName string
+		var attributeName string
+		var attributeTypeName string
+		err = rows.Scan(&columnName, &typeName, &attributeName, &attributeTypeName)
+		if err != nil {
+			return nil, err
+		}
+
+		// composite type attributes are always nullable, values of unknown types are kept as row literal text
+		fieldType := g.mapNullableTypeName(g.mapDatabaseType(attributeTypeName))
+		if !strings.HasPrefix(fieldType, "sql.") {
+			fieldType = "sql.NullString"
+		}
+
+		composite := composites[columnName]
+		composite.Name = StringCaseConverter{}.ToPascalCase(typeName)
+		composite.HasTime = composite.HasTime || fieldType == "sql.NullTime"
+		composite.Fields = append(
+			composite.Fields, DatabaseCompositeField{
+				Name:   attributeName,
+				Type:   fieldType,
+				IsTime: fieldType == "sql.NullTime",
+			},
+		)
+		composites[columnName] = composite
+	}
+
+	return composites, rows.Err()
+}
+
+// createDomains creates named types for columns of domain types and replaces types of these fields with named types.
+// Fields of enum and composite types are not changed.
+func (g *DtoGenerator) createDomains(fields []DatabaseField) []DatabaseDomain {
+	if !g.withDomainTypes {
+		return nil
+	}
+
+	domainBaseTypes := map[string]struct{}{"bool": {}, "float64": {}, "int64": {}, "string": {}, "uint64": {}}
+
+	domainsMap := make(map[string]DatabaseDomain)
+	for i, field := range fields {
+		if field.DomainName == "" {
+			continue
+		}
+
+		baseType := g.mapDatabaseType(field.DatabaseType)
+		if _, ok := domainBaseTypes[baseType]; !ok {
+			continue
+		}
+
+		if field.Type != baseType && field.Type != g.mapNullableTypeName(baseType) {
+			continue
+		}
+
+		domain := DatabaseDomain{
+			Name: StringCaseConverter{}.ToPascalCase(field.DomainName),
+			Type: baseType,
+		}
+
+		fields[i].Type = domain.Name
+		if field.IsNullable {
+			fields[i].Type = "*" + domain.Name
+		}
+
+		domainsMap[domain.Name] = domain
+	}
 
-	return fields, nil
+	domains := make([]DatabaseDomain, 0, len(domainsMap))
+	for _, domain := range domainsMap {
+		domains = append(domains, domain)
+	}
+
+	sort.Slice(
+		domains, func(i, j int) bool {
+			return domains[i].Name < domains[j].Name
+		},
+	)
+
+	return domains
+}
+
+// buildTags returns struct tags of DTO field, starting with "db" tag
+func (g *DtoGenerator) buildTags(field DatabaseField) (string, error) {
+	tags := []string{fmt.Sprintf(`db:"%s"`, field.Name)}
+
+	for _, tag := range g.tags {
+		var name string
+		switch tag.Naming {
+		case DtoTagNamingColumn:
+			name = field.Name
+		case DtoTagNamingSnake:
+			name = StringCaseConverter{}.ToSnakeCase(field.Name)
+		case DtoTagNamingCamel:
+			name = StringCaseConverter{}.ToCamelCase(field.Name)
+		default:
+			return "", fmt.Errorf("unknown tag naming \"%s\"", tag.Naming)
+		}
+
+		var value string
+		switch tag.Kind {
+		case DtoTagKindJSON:
+			value = name
+			if field.IsNullable {
+				value += ",omitempty"
+			}
+		case DtoTagKindValidate:
+			if field.IsNullable {
+				continue
+			}
+
+			value = "required"
+		case DtoTagKindGorm:
+			value = "column:" + name
+			if field.IsPrimaryKey {
+				value += ";primaryKey"
+			}
+			if !field.IsNullable {
+				value += ";not null"
+			}
+		case DtoTagKindBun:
+			value = name
+			if field.IsPrimaryKey {
+				value += ",pk"
+			}
+			if !field.IsNullable {
+				value += ",notnull"
+			}
+		default:
+			return "", fmt.Errorf("unknown tag kind \"%s\"", tag.Kind)
+		}
+
+		tags = append(tags, fmt.Sprintf(`%s:"%s"`, tag.Kind, value))
+	}
+
+	return strings.Join(tags, " "), nil
 }
 
 func (*DtoGenerator) parseSchemaAndTableName(tableName string) (string, string) {
@@ -206,6 +785,7 @@ func (*DtoGenerator) mapDatabaseType(databaseTypeName string) string {
 		databaseFieldTypeSmallint:         "int64",
 		databaseFieldTypeText:             "string",
 		databaseFieldTypeTimestamp:        "time.Time",
+		databaseFieldTypeTimestamptz:      "time.Time",
 		databaseFieldTypeTinyint:          "int64",
 		databaseFieldTypeUnsignedBigInt:   "uint64",
 		databaseFieldTypeVarchar:          "string",
@@ -239,7 +819,7 @@ func (*DtoGenerator) mapNullableTypeName(typeName string) string {
 	return typeName
 }
 
-func (*DtoGenerator) createImports(fields []databaseField) []string {
+func (*DtoGenerator) createImports(fields []DatabaseField) []string {
 	importsMap := map[string]string{
 		"time.Time":       "time",
 		"[]sql.NullByte":  "database/sql",
@@ -264,3 +844,24 @@ func (*DtoGenerator) createImports(fields []databaseField) []string {
 
 	return imports
 }
+
+// appendImports adds import paths to sorted imports, skipping already imported paths
+func (*DtoGenerator) appendImports(imports []string, importPaths ...string) []string {
+	for _, importPath := range importPaths {
+		isImported := false
+		for _, existingImport := range imports {
+			if existingImport == importPath {
+				isImported = true
+				break
+			}
+		}
+
+		if !isImported {
+			imports = append(imports, importPath)
+		}
+	}
+
+	sort.Strings(imports)
+
+	return imports
+}
@@ -0,0 +1,73 @@
+package gorep
+
+import (
+	"io/fs"
+	"text/template"
+)
+
+type DtoGeneratorOption func(generator *DtoGenerator)
+
+// WithDtoTemplate replaces DTO template with template contents
+func WithDtoTemplate(templateContents string) DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.templateDTO = templateContents
+	}
+}
+
+// WithDtoTemplateFS replaces DTO template with template files of file system, matched by patterns.
+// File system must contain "dto.template" file, other matched files could be used as associated templates.
+// If no patterns are set, only "dto.template" file is parsed.
+func WithDtoTemplateFS(templateFileSystem fs.FS, templatePatterns ...string) DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.templateFileSystem = templateFileSystem
+		generator.templatePatterns = templatePatterns
+	}
+}
+
+// WithDtoTemplateFunctions adds custom functions to DTO template, overriding default functions with same names
+func WithDtoTemplateFunctions(templateFunctions template.FuncMap) DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.templateFunctions = templateFunctions
+	}
+}
+
+// WithDtoTags adds struct tags to DTO fields after "db" tag. Tags flags are derived from column nullability
+// and primary key.
+func WithDtoTags(tags ...DtoTag) DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.tags = tags
+	}
+}
+
+// WithDtoEnums makes columns of PostgreSQL enum types to be generated as Go string types with constants for each
+// enum label. Enum types are declared in DTO file.
+func WithDtoEnums() DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.withEnums = true
+	}
+}
+
+// WithDtoCheckEnums makes string columns with "CHECK (column IN (...))" constraints to be generated as Go string types
+// with constants for each allowed value. Enum types are named after table and column and declared in DTO file.
+func WithDtoCheckEnums() DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.withCheckEnums = true
+	}
+}
+
+// WithDtoDomainTypes makes columns of PostgreSQL domain types to be generated as named Go types, for example
+// "type Email string". Domains with base types other than string, number or boolean are generated as base types.
+// Domain types are declared in DTO file.
+func WithDtoDomainTypes() DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.withDomainTypes = true
+	}
+}
+
+// WithDtoCompositeTypes makes columns of PostgreSQL composite types to be generated as Go structures, implementing
+// sql.Scanner and driver.Valuer for composite row literal format. Composite types are declared in DTO file.
+func WithDtoCompositeTypes() DtoGeneratorOption {
+	return func(generator *DtoGenerator) {
+		generator.withCompositeTypes = true
+	}
+}
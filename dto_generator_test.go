@@ -1,17 +1,20 @@
 package gorep
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/andreyvit/diff"
 	"github.com/golang/mock/gomock"
 	_ "github.com/lib/pq"
 
 	"github.com/vehsamrak/gorep/test_data"
+	"github.com/vehsamrak/gorep/test_tools"
 )
 
 func TestDtoGenerator_Generate_testDatabase(t *testing.T) {
@@ -88,6 +91,7 @@ func TestDtoGenerator_Generate_testDatabase(t *testing.T) {
 						"value_text":                   databaseFieldTypeText,
 						"value_timestamp":              databaseFieldTypeTimestamp,
 						"value_timestamp_not_nullable": makeNotNullable(databaseFieldTypeTimestamp),
+						"value_timestamptz":            databaseFieldTypeTimestamptz,
 						"value_varchar":                databaseFieldTypeVarchar,
 					},
 				)
@@ -153,6 +157,23 @@ func TestDtoGenerator_Generate_testDatabase(t *testing.T) {
 	}
 }
 
+func TestDtoGenerator_Generate_TableNameWithQuote(t *testing.T) {
+	const tableName = "test'quote"
+
+	dropTable(testDatabase, `"`+tableName+`"`)
+	createTable(testDatabase, `"`+tableName+`"`, map[string]string{"id": makeNotNullable(databaseFieldTypeInt)})
+	defer dropTable(testDatabase, `"`+tableName+`"`)
+
+	result, err := NewDtoGenerator(testDatabase).Generate("package_name", tableName)
+
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if !strings.Contains(result, "`db:\"id\"`") {
+		t.Errorf("Generate() result must contain column of table with quote in name, returned:\n%s", result)
+	}
+}
+
 func TestDtoGenerator_Generate_mockDatabase(t *testing.T) {
 	const (
 		packageName = "package_name"
@@ -184,7 +205,7 @@ func TestDtoGenerator_Generate_mockDatabase(t *testing.T) {
 				tableName:   tableName,
 			},
 			mockBehaviour: func() {
-				mockDatabase.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				mockDatabase.EXPECT().QueryContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
 			},
 			expected:      "",
 			expectedError: true,
@@ -213,6 +234,30 @@ func TestDtoGenerator_Generate_mockDatabase(t *testing.T) {
 	}
 }
 
+func TestDtoGenerator_GenerateContext(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	t.Run(
+		"cancelled context, must pass context to database and return its error", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			mockDatabase := test_data.NewMockDatabase(mockController)
+			mockDatabase.EXPECT().QueryContext(ctx, gomock.Any(), gomock.Any()).Return(nil, ctx.Err())
+			generator := NewDtoGenerator(mockDatabase)
+
+			result, err := generator.GenerateContext(ctx, "package_name", "test")
+
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("GenerateContext() must return error \"%v\", returned \"%v\"", context.Canceled, err)
+			}
+			if result != "" {
+				t.Errorf("GenerateContext() must return empty result, returned \"%s\"", result)
+			}
+		},
+	)
+}
+
 func TestDtoGenerator_Generate_InvalidTemplate(t *testing.T) {
 	mockController := gomock.NewController(t)
 	defer mockController.Finish()
@@ -261,6 +306,261 @@ func TestDtoGenerator_Generate_InvalidTemplate(t *testing.T) {
 	)
 }
 
+func TestDtoGenerator_Generate_CustomTemplate(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	t.Run(
+		"custom template contents, must return DTO generated with custom template", func(t *testing.T) {
+			const (
+				tableName   = "test"
+				packageName = "package_name"
+			)
+			dropTable(testDatabase, tableName)
+			createTable(
+				testDatabase, tableName, map[string]string{
+					"id":   databaseFieldTypeSerial,
+					"name": databaseFieldTypeText,
+				},
+			)
+			expected := "package_name test id:int64 name:sql.NullString"
+			generator := NewDtoGenerator(
+				testDatabase,
+				WithDtoTemplate(`{{ .PackageName }} {{ .TableName }}{{ range .Fields }} {{ .Name }}:{{ .Type }}{{ end }}`),
+			)
+
+			result, err := generator.Generate(packageName, tableName)
+
+			if err != nil {
+				t.Errorf("Generate() returned error: %v", err)
+			}
+			if result != expected {
+				t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+			}
+		},
+	)
+
+	t.Run(
+		"custom template file system without DTO template, must return error", func(t *testing.T) {
+			database := test_data.NewMockDatabase(mockController)
+			templateFileSystem := fstest.MapFS{
+				"header.template": &fstest.MapFile{Data: []byte("package {{ .PackageName }}")},
+			}
+			expectedErrorMessage := "pattern matches no files"
+			generator := NewDtoGenerator(database, WithDtoTemplateFS(templateFileSystem))
+
+			_, err := generator.Generate("package_name", "table_name")
+
+			if err == nil || !strings.Contains(err.Error(), expectedErrorMessage) {
+				t.Errorf("Generate() must return error \"%s\", returned \"%s\"", expectedErrorMessage, err)
+			}
+		},
+	)
+}
+
+func TestDtoGenerator_Generate_Tags(t *testing.T) {
+	const (
+		packageName               = "package_name"
+		tableName                 = "test"
+		testDtoWithTagsGoldenPath = "test_data/test_dto_with_tags.golden"
+	)
+
+	tests := []struct {
+		name          string
+		tags          []DtoTag
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "json, validate, gorm and bun tags, must return DTO with tags derived from columns",
+			tags: []DtoTag{
+				{Kind: DtoTagKindJSON, Naming: DtoTagNamingCamel},
+				{Kind: DtoTagKindValidate},
+				{Kind: DtoTagKindGorm},
+				{Kind: DtoTagKindBun, Naming: DtoTagNamingSnake},
+			},
+			expected:      test_tools.GetFileContents(testDtoWithTagsGoldenPath),
+			expectedError: "",
+		},
+		{
+			name:          "unknown tag kind, must return error",
+			tags:          []DtoTag{{Kind: "xml"}},
+			expected:      "",
+			expectedError: "unknown tag kind \"xml\"",
+		},
+		{
+			name:          "unknown tag naming, must return error",
+			tags:          []DtoTag{{Kind: DtoTagKindJSON, Naming: "screaming"}},
+			expected:      "",
+			expectedError: "unknown tag naming \"screaming\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				dropTable(testDatabase, tableName)
+				createTable(
+					testDatabase, tableName, map[string]string{
+						"id":        databaseFieldTypeSerial + " PRIMARY KEY",
+						"user_name": makeNotNullable(databaseFieldTypeVarchar),
+						"nick_name": databaseFieldTypeText,
+					},
+				)
+
+				generator := NewDtoGenerator(testDatabase, WithDtoTags(tt.tags...))
+				result, err := generator.Generate(packageName, tableName)
+
+				if tt.expectedError == "" && err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+					t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+				}
+				if result != tt.expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, tt.expected))
+				}
+			},
+		)
+	}
+}
+
+func TestDtoGenerator_Generate_Enums(t *testing.T) {
+	const (
+		packageName                = "package_name"
+		tableName                  = "test"
+		enumTypeName               = "test_status"
+		testDtoWithEnumsGoldenPath = "test_data/test_dto_with_enums.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec(
+		fmt.Sprintf("DROP TYPE IF EXISTS %[1]s; CREATE TYPE %[1]s AS ENUM ('new', 'in_progress')", enumTypeName),
+	)
+	if err != nil {
+		t.Fatalf("enum type creation error: %v", err)
+	}
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":              databaseFieldTypeSerial + " PRIMARY KEY",
+			"status":          makeNotNullable(enumTypeName),
+			"previous_status": enumTypeName,
+			"kind":            makeNotNullable(databaseFieldTypeVarchar) + " CHECK (kind IN ('retail', 'whole sale'))",
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	expected := test_tools.GetFileContents(testDtoWithEnumsGoldenPath)
+
+	generator := NewDtoGenerator(testDatabase, WithDtoEnums(), WithDtoCheckEnums())
+	result, err := generator.Generate(packageName, tableName)
+
+	if err != nil {
+		t.Errorf("Generate() returned error: %v", err)
+	}
+	if result != expected {
+		t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+	}
+}
+
+func TestDtoGenerator_Generate_EnumLabelsCollision(t *testing.T) {
+	const (
+		packageName  = "package_name"
+		tableName    = "test"
+		enumTypeName = "test_status"
+	)
+
+	tests := []struct {
+		name          string
+		enumLabels    string
+		checkLabels   string
+		expectedError string
+	}{
+		{
+			name:          "enum type labels with the same name, must return error",
+			enumLabels:    "'new', 'in-progress', 'in_progress'",
+			checkLabels:   "'retail'",
+			expectedError: "enum labels \"in-progress\" and \"in_progress\" have the same constant name TestStatusInProgress",
+		},
+		{
+			name:          "check constraint labels with the same name, must return error",
+			enumLabels:    "'new'",
+			checkLabels:   "'A b', 'a_b'",
+			expectedError: "enum labels \"A b\" and \"a_b\" have the same constant name TestKindAB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				dropTable(testDatabase, tableName)
+				_, err := testDatabase.Exec(
+					fmt.Sprintf("DROP TYPE IF EXISTS %[1]s; CREATE TYPE %[1]s AS ENUM (%[2]s)", enumTypeName, tt.enumLabels),
+				)
+				if err != nil {
+					t.Fatalf("enum type creation error: %v", err)
+				}
+				createTable(
+					testDatabase, tableName, map[string]string{
+						"id":     databaseFieldTypeSerial + " PRIMARY KEY",
+						"status": makeNotNullable(enumTypeName),
+						"kind":   makeNotNullable(databaseFieldTypeVarchar) + fmt.Sprintf(" CHECK (kind IN (%s))", tt.checkLabels),
+					},
+				)
+				defer dropTable(testDatabase, tableName)
+
+				generator := NewDtoGenerator(testDatabase, WithDtoEnums(), WithDtoCheckEnums())
+				_, err = generator.Generate(packageName, tableName)
+
+				if err == nil || err.Error() != tt.expectedError {
+					t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+				}
+			},
+		)
+	}
+}
+
+func TestDtoGenerator_Generate_DomainsAndComposites(t *testing.T) {
+	const (
+		packageName                     = "package_name"
+		tableName                       = "test"
+		domainTypeName                  = "test_email"
+		compositeTypeName               = "test_address"
+		testDtoWithCompositesGoldenPath = "test_data/test_dto_with_composites.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec(
+		fmt.Sprintf(
+			`DROP DOMAIN IF EXISTS %[1]s; CREATE DOMAIN %[1]s AS text CHECK (VALUE LIKE '%%@%%');
+			DROP TYPE IF EXISTS %[2]s; CREATE TYPE %[2]s AS (street text, house int4, built_at timestamp)`,
+			domainTypeName,
+			compositeTypeName,
+		),
+	)
+	if err != nil {
+		t.Fatalf("domain and composite types creation error: %v", err)
+	}
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":               databaseFieldTypeSerial + " PRIMARY KEY",
+			"email":            makeNotNullable(domainTypeName),
+			"backup_email":     domainTypeName,
+			"address":          makeNotNullable(compositeTypeName),
+			"previous_address": compositeTypeName,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	expected := test_tools.GetFileContents(testDtoWithCompositesGoldenPath)
+
+	generator := NewDtoGenerator(testDatabase, WithDtoDomainTypes(), WithDtoCompositeTypes())
+	result, err := generator.Generate(packageName, tableName)
+
+	if err != nil {
+		t.Errorf("Generate() returned error: %v", err)
+	}
+	if result != expected {
+		t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+	}
+}
+
 func makeNotNullable(typeName string) string {
 	return fmt.Sprintf("%s NOT NULL", typeName)
 }
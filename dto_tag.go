@@ -0,0 +1,33 @@
+package gorep
+
+// DtoTagKind is kind of DTO field struct tag
+type DtoTagKind string
+
+const (
+	// DtoTagKindJSON is json tag with "omitempty" flag for nullable columns, `json:"name,omitempty"`
+	DtoTagKindJSON DtoTagKind = "json"
+	// DtoTagKindValidate is validator tag with "required" rule for NOT NULL columns, `validate:"required"`
+	DtoTagKindValidate DtoTagKind = "validate"
+	// DtoTagKindGorm is GORM tag with column name and primary key flag, `gorm:"column:id;primaryKey"`
+	DtoTagKindGorm DtoTagKind = "gorm"
+	// DtoTagKindBun is bun tag with column name, primary key and NOT NULL flags, `bun:"id,pk,notnull"`
+	DtoTagKindBun DtoTagKind = "bun"
+)
+
+// DtoTagNaming is naming style of column name in DTO field struct tag
+type DtoTagNaming string
+
+const (
+	// DtoTagNamingColumn keeps column name as is
+	DtoTagNamingColumn DtoTagNaming = ""
+	// DtoTagNamingSnake converts column name to snake case, "user_id"
+	DtoTagNamingSnake DtoTagNaming = "snake"
+	// DtoTagNamingCamel converts column name to camel case, "userId"
+	DtoTagNamingCamel DtoTagNaming = "camel"
+)
+
+// DtoTag is struct tag, added to DTO fields besides "db" tag
+type DtoTag struct {
+	Kind   DtoTagKind
+	Naming DtoTagNaming
+}
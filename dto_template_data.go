@@ -0,0 +1,21 @@
+package gorep
+
+// DtoTemplateData is data, passed to DTO template
+type DtoTemplateData struct {
+	// PackageName is package name of generated DTO
+	PackageName string
+	// TableName is database table name without schema
+	TableName string
+	// RelationKind is kind of relation: table, view or materialized view
+	RelationKind RelationKind
+	// Fields are table columns, sorted by name
+	Fields []DatabaseField
+	// Imports are import paths of packages, used by field types, enums, domains and composite types
+	Imports []string
+	// Enums are enum types, used by fields, sorted by name
+	Enums []DatabaseEnum
+	// Domains are named domain types, used by fields, sorted by name
+	Domains []DatabaseDomain
+	// Composites are composite types, used by fields, sorted by name
+	Composites []DatabaseComposite
+}
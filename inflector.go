@@ -0,0 +1,71 @@
+package gorep
+
+import "strings"
+
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"foot":   "feet",
+	"man":    "men",
+	"mouse":  "mice",
+	"person": "people",
+	"tooth":  "teeth",
+	"woman":  "women",
+}
+
+// Inflector converts english nouns between singular and plural forms, respecting only common rules
+type Inflector struct {
+}
+
+// Plural returns plural form of singular noun, such as "categories" for "category"
+func (Inflector) Plural(noun string) string {
+	lowercaseNoun := strings.ToLower(noun)
+	if plural, ok := irregularPlurals[lowercaseNoun]; ok {
+		return plural
+	}
+
+	switch {
+	case noun == "":
+		return ""
+	case strings.HasSuffix(lowercaseNoun, "s"),
+		strings.HasSuffix(lowercaseNoun, "x"),
+		strings.HasSuffix(lowercaseNoun, "z"),
+		strings.HasSuffix(lowercaseNoun, "ch"),
+		strings.HasSuffix(lowercaseNoun, "sh"):
+		return noun + "es"
+	case strings.HasSuffix(lowercaseNoun, "y") && len(noun) > 1 && !isVowel(lowercaseNoun[len(noun)-2]):
+		return noun[:len(noun)-1] + "ies"
+	}
+
+	return noun + "s"
+}
+
+// Singular returns singular form of plural noun, such as "category" for "categories"
+func (Inflector) Singular(noun string) string {
+	lowercaseNoun := strings.ToLower(noun)
+	for singular, plural := range irregularPlurals {
+		if lowercaseNoun == plural {
+			return singular
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lowercaseNoun, "ies") && len(noun) > 3:
+		return noun[:len(noun)-3] + "y"
+	case strings.HasSuffix(lowercaseNoun, "sses"),
+		strings.HasSuffix(lowercaseNoun, "xes"),
+		strings.HasSuffix(lowercaseNoun, "zes"),
+		strings.HasSuffix(lowercaseNoun, "ches"),
+		strings.HasSuffix(lowercaseNoun, "shes"):
+		return noun[:len(noun)-2]
+	case strings.HasSuffix(lowercaseNoun, "ss"), strings.HasSuffix(lowercaseNoun, "us"):
+		return noun
+	case strings.HasSuffix(lowercaseNoun, "s"):
+		return noun[:len(noun)-1]
+	}
+
+	return noun
+}
+
+func isVowel(letter byte) bool {
+	return strings.IndexByte("aeiou", letter) >= 0
+}
@@ -1,3 +1,4 @@
 package gorep
 
 //go:generate mockgen -destination=test_data/mock_database.go -package=test_data github.com/vehsamrak/gorep Database
+//go:generate mockgen -destination=test_data/mock_connection_database.go -package=test_data github.com/vehsamrak/gorep ConnectionDatabase
@@ -0,0 +1,12 @@
+package gorep
+
+type modelDeclaration struct {
+	Key       string
+	Name      string
+	Start     int
+	End       int
+	BodyStart int
+	BodyEnd   int
+	Body      string
+	Text      string
+}
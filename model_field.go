@@ -1,7 +1,27 @@
 package gorep
 
-type modelField struct {
-	Name       string
-	Type       string
+// ModelField is DTO structure field, passed to model template
+type ModelField struct {
+	// Name is DTO field name
+	Name string
+	// Type is Go type of model field
+	Type string
+	// DTOType is Go type of DTO field, differs from Type for unwrapped nullable fields
+	DTOType string
+	// Column is database column name from "db" tag of DTO field
+	Column string
+	// StructName is model structure name
 	StructName string
+	// IsNullable is true for DTO fields of "sql.Null*" or pointer types
+	IsNullable bool
+	// IsEmbedded is true for embedded fields, which are kept embedded in model
+	IsEmbedded bool
+	// NullableValueField is value field name of "sql.Null*" type for unwrapped nullable fields, such as "String"
+	NullableValueField string
+	// NullableNotZero is condition, checking that unwrapped to zero value field is not zero
+	NullableNotZero string
+	// IsUnwrappedToPointer is true for nullable fields, unwrapped to pointers
+	IsUnwrappedToPointer bool
+	// IsUnwrappedToZeroValue is true for nullable fields, unwrapped to zero values
+	IsUnwrappedToZeroValue bool
 }
@@ -2,28 +2,62 @@ package gorep
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
+	"io/fs"
+	"path"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 )
 
 //go:embed model.template
 var templateFileModel string
 
 type ModelGenerator struct {
-	templateModel string
+	templateModel      string
+	templateFileSystem fs.FS
+	templatePatterns   []string
+	templateFunctions  template.FuncMap
+	withMappers        bool
+	withSetters        bool
+	withCopyMethods    bool
+	withChangeTracking bool
+	nullableUnwrapping NullableUnwrapping
 }
 
-func NewModelGenerator() *ModelGenerator {
-	return &ModelGenerator{templateModel: templateFileModel}
+func NewModelGenerator(options ...ModelGeneratorOption) *ModelGenerator {
+	generator := &ModelGenerator{templateModel: templateFileModel}
+	for _, option := range options {
+		option(generator)
+	}
+
+	return generator
 }
 
 func (g *ModelGenerator) Generate(packageName string, dtoFileContents string) (string, error) {
+	return g.GenerateContext(context.Background(), packageName, dtoFileContents)
+}
+
+// GenerateContext generates model from DTO file contents. Model generation does not use database, so context is
+// only checked before generation, to keep generators interchangeable.
+func (g *ModelGenerator) GenerateContext(
+	ctx context.Context,
+	packageName string,
+	dtoFileContents string,
+) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	if dtoFileContents == "" {
 		return "", fmt.Errorf("dto file contents must not be empty")
 	}
@@ -38,54 +72,36 @@ func (g *ModelGenerator) Generate(packageName string, dtoFileContents string) (s
 		return "", fmt.Errorf("dto file contents parsing error: %w", err)
 	}
 
-	var structName string
-	ast.Inspect(
-		file, func(astNode ast.Node) bool {
-			astTypeSpec, ok := astNode.(*ast.TypeSpec)
-			if !ok {
-				return true
-			}
-
-			structName = g.removeDTOFromStructName(astTypeSpec.Name.Name)
-
-			return false
-		},
-	)
-
-	var modelFields []modelField
-	ast.Inspect(
-		file, func(astNode ast.Node) bool {
-			astStruct, ok := astNode.(*ast.StructType)
-			if !ok {
-				return true
-			}
-
-			for _, field := range astStruct.Fields.List {
-				currentField := field.Names[0]
-				if !currentField.IsExported() {
-					continue
-				}
-
-				fieldType := dtoFileContents[(field.Type.Pos() - 1):(field.Type.End() - 1)]
+	structTypes := make(map[string]*ast.StructType)
+	var structTypeNames []string
+	for _, declaration := range file.Decls {
+		genericDeclaration, ok := declaration.(*ast.GenDecl)
+		if !ok || genericDeclaration.Tok != token.TYPE {
+			continue
+		}
 
-				modelFields = append(
-					modelFields, modelField{
-						Name:       currentField.Name,
-						Type:       fieldType,
-						StructName: structName,
-					},
-				)
+		for _, spec := range genericDeclaration.Specs {
+			astTypeSpec := spec.(*ast.TypeSpec)
+			if astStruct, ok := astTypeSpec.Type.(*ast.StructType); ok {
+				structTypes[astTypeSpec.Name.Name] = astStruct
+				structTypeNames = append(structTypeNames, astTypeSpec.Name.Name)
 			}
+		}
+	}
 
-			return false
-		},
-	)
-
-	if structName == "" {
+	dtoStructName := g.findDTOStructName(structTypeNames, structTypes)
+	if dtoStructName == "" {
 		return "", fmt.Errorf("no DTO structure was found in DTO contents")
 	}
 
-	// TODO[petr]: if model file not exist
+	structName := g.removeDTOFromStructName(dtoStructName)
+	modelFields := g.collectFields(
+		dtoFileContents,
+		structTypes[dtoStructName],
+		structTypes,
+		structName,
+		map[string]struct{}{dtoStructName: {}},
+	)
 
 	if len(modelFields) == 0 {
 		return "", fmt.Errorf("no fields found in DTO")
@@ -97,28 +113,40 @@ func (g *ModelGenerator) Generate(packageName string, dtoFileContents string) (s
 		},
 	)
 
-	imports := g.createImports(modelFields)
-
-	data := struct {
-		PackageName string
-		StructName  string
-		Fields      []modelField
-		Imports     []string
-	}{
-		PackageName: packageName,
-		StructName:  structName,
-		Fields:      modelFields,
-		Imports:     imports,
-	}
-
-	templator, err := template.New("model.template").
-		Funcs(
-			template.FuncMap{
-				"Uppercase": StringCaseConverter{}.SnakeCaseToCamelCase,
-				"Lowercase": StringCaseConverter{}.Lowercase,
-			},
-		).
-		Parse(g.templateModel)
+	modelFields = g.unwrapNullableFields(modelFields)
+
+	imports, err := g.createImports(modelFields, file.Imports)
+	if err != nil {
+		return "", err
+	}
+
+	var trackedColumns []string
+	for _, field := range modelFields {
+		if !field.IsEmbedded {
+			trackedColumns = append(trackedColumns, field.Column)
+		}
+	}
+
+	data := ModelTemplateData{
+		PackageName:        packageName,
+		StructName:         structName,
+		DTOStructName:      dtoStructName,
+		Fields:             modelFields,
+		Imports:            imports,
+		TrackedColumns:     trackedColumns,
+		WithMappers:        g.withMappers,
+		WithSetters:        g.withSetters,
+		WithCopyMethods:    g.withCopyMethods,
+		WithChangeTracking: g.withChangeTracking,
+	}
+
+	templator, err := parseTemplate(
+		"model.template",
+		g.templateModel,
+		g.templateFileSystem,
+		g.templatePatterns,
+		mergeTemplateFunctions(g.templateFunctions),
+	)
 	if err != nil {
 		return "", err
 	}
@@ -130,33 +158,608 @@ func (g *ModelGenerator) Generate(packageName string, dtoFileContents string) (s
 	}
 
 	return buffer.String(), nil
+}
+
+// Regenerate generates model from DTO file contents and merges it into existing model file contents.
+// Generator-owned parts (model structure, constructor and generated methods) are replaced, while all other
+// declarations, comments and methods of model file are preserved. Empty model file contents are generated from scratch.
+func (g *ModelGenerator) Regenerate(packageName string, dtoFileContents string, modelFileContents string) (string, error) {
+	generatedContents, err := g.Generate(packageName, dtoFileContents)
+	if err != nil {
+		return "", err
+	}
+
+	if modelFileContents == "" {
+		return generatedContents, nil
+	}
+
+	fileSet := token.NewFileSet()
+	modelFile, err := parser.ParseFile(fileSet, "model.go", modelFileContents, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("model file contents parsing error: %w", err)
+	}
+
+	generatedFile, err := parser.ParseFile(fileSet, "generated.go", generatedContents, 0)
+	if err != nil {
+		return "", fmt.Errorf("generated model parsing error: %w", err)
+	}
+
+	generatedDeclarations := g.findModelDeclarations(fileSet, generatedFile, generatedContents)
+	existingDeclarations := g.findModelDeclarations(fileSet, modelFile, modelFileContents)
+
+	generatedDeclarationsMap := make(map[string]modelDeclaration, len(generatedDeclarations))
+	for _, declaration := range generatedDeclarations {
+		generatedDeclarationsMap[declaration.Key] = declaration
+	}
+
+	if len(generatedDeclarations) == 0 {
+		return "", fmt.Errorf("no model structure was found in generated model")
+	}
+
+	ownedMethodKeys := g.findOwnedMethodKeys(modelFile, generatedDeclarations[0].Name)
+
+	var replacements []textReplacement
+	replacedKeys := make(map[string]struct{})
+	for _, declaration := range existingDeclarations {
+		generatedDeclaration, isGenerated := generatedDeclarationsMap[declaration.Key]
+		if isGenerated {
+			replacements = append(
+				replacements, textReplacement{
+					Start: declaration.BodyStart,
+					End:   declaration.BodyEnd,
+					Text:  generatedDeclaration.Body,
+				},
+			)
+			replacedKeys[declaration.Key] = struct{}{}
 
-	// TODO[petr]: if model file exist
+			continue
+		}
+
+		if _, isOwned := ownedMethodKeys[declaration.Key]; isOwned {
+			replacements = append(replacements, textReplacement{Start: declaration.Start, End: declaration.End})
+		}
+	}
+
+	var appendedDeclarations strings.Builder
+	for _, declaration := range generatedDeclarations {
+		if _, ok := replacedKeys[declaration.Key]; !ok {
+			appendedDeclarations.WriteString("\n\n")
+			appendedDeclarations.WriteString(declaration.Text)
+		}
+	}
+
+	replacements = append(
+		replacements, textReplacement{
+			Start: len(modelFileContents),
+			End:   len(modelFileContents),
+			Text:  appendedDeclarations.String(),
+		},
+	)
+
+	mergedContents := g.replaceText(modelFileContents, replacements)
+
+	mergedContents, err = g.mergeImports(mergedContents, generatedFile.Imports, generatedContents, fileSet)
+	if err != nil {
+		return "", err
+	}
+
+	formattedContents, err := format.Source([]byte(mergedContents))
+	if err != nil {
+		return "", fmt.Errorf("merged model formatting error: %w", err)
+	}
+
+	return string(formattedContents), nil
 }
 
-func (*ModelGenerator) createImports(fields []modelField) []string {
-	importsMap := map[string]string{
-		"time.Time":       "time",
-		"[]sql.NullByte":  "database/sql",
-		"sql.NullBool":    "database/sql",
-		"sql.NullFloat64": "database/sql",
-		"sql.NullString":  "database/sql",
-		"sql.NullTime":    "database/sql",
-		"sql.NullInt64":   "database/sql",
+// createImports resolves packages referenced by field types using DTO file imports, aliases are kept
+func (g *ModelGenerator) createImports(fields []ModelField, fileImports []*ast.ImportSpec) ([]ModelImport, error) {
+	knownImports := map[string]ModelImport{
+		"sql":  {Path: "database/sql"},
+		"time": {Path: "time"},
+	}
+
+	for _, importSpec := range fileImports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			return nil, fmt.Errorf("dto file import parsing error: %w", err)
+		}
+
+		if importSpec.Name == nil {
+			knownImports[g.importPathToPackageName(importPath)] = ModelImport{Path: importPath}
+			continue
+		}
+
+		if importSpec.Name.Name == "_" || importSpec.Name.Name == "." {
+			continue
+		}
+
+		knownImports[importSpec.Name.Name] = ModelImport{Alias: importSpec.Name.Name, Path: importPath}
 	}
 
 	alreadyImported := make(map[string]struct{})
-	var imports []string
+	var imports []ModelImport
 	for _, field := range fields {
-		if importPackage, ok := importsMap[field.Type]; ok {
-			if _, ok := alreadyImported[importPackage]; !ok {
+		fieldTypes := []string{field.Type}
+		if g.withMappers && field.DTOType != field.Type {
+			fieldTypes = append(fieldTypes, field.DTOType)
+		}
+
+		var packageNames []string
+		for _, fieldType := range fieldTypes {
+			typePackageNames, err := g.findTypePackageNames(fieldType)
+			if err != nil {
+				return nil, err
+			}
+
+			packageNames = append(packageNames, typePackageNames...)
+		}
+
+		for _, packageName := range packageNames {
+			importPackage, ok := knownImports[packageName]
+			if !ok {
+				return nil, fmt.Errorf("package \"%s\" of field \"%s\" is not imported in DTO file", packageName, field.Name)
+			}
+
+			if _, ok := alreadyImported[importPackage.Path]; !ok {
 				imports = append(imports, importPackage)
-				alreadyImported[importPackage] = struct{}{}
+				alreadyImported[importPackage.Path] = struct{}{}
+			}
+		}
+	}
+
+	sort.Slice(
+		imports, func(i, j int) bool {
+			return imports[i].Path < imports[j].Path
+		},
+	)
+
+	return imports, nil
+}
+
+// unwrapNullableFields replaces "sql.Null*" field types with their value types according to nullable unwrapping mode
+func (g *ModelGenerator) unwrapNullableFields(fields []ModelField) []ModelField {
+	for i, field := range fields {
+		fields[i].DTOType = field.Type
+		fields[i].IsNullable = strings.HasPrefix(field.Type, "*") || strings.HasPrefix(field.Type, "sql.Null")
+
+		nullable, ok := nullableTypes[field.Type]
+		if !ok || field.IsEmbedded {
+			continue
+		}
+
+		switch g.nullableUnwrapping {
+		case NullableUnwrappingPointer:
+			fields[i].Type = "*" + nullable.Type
+			fields[i].NullableValueField = nullable.ValueField
+			fields[i].IsUnwrappedToPointer = true
+		case NullableUnwrappingZeroValue:
+			fields[i].Type = nullable.Type
+			fields[i].NullableValueField = nullable.ValueField
+			fields[i].NullableNotZero = fmt.Sprintf(
+				nullable.NotZeroFormat,
+				"m."+goIdentifier(StringCaseConverter{}.Lowercase(field.Name)),
+			)
+			fields[i].IsUnwrappedToZeroValue = true
+		}
+	}
+
+	return fields
+}
+
+// findTypePackageNames returns names of packages used in type expression, such as "time" in "[]time.Time"
+func (*ModelGenerator) findTypePackageNames(typeName string) ([]string, error) {
+	typeExpression, err := parser.ParseExpr(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("field type \"%s\" parsing error: %w", typeName, err)
+	}
+
+	var packageNames []string
+	ast.Inspect(
+		typeExpression, func(astNode ast.Node) bool {
+			selector, ok := astNode.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if packageIdent, ok := selector.X.(*ast.Ident); ok {
+				packageNames = append(packageNames, packageIdent.Name)
+			}
+
+			return false
+		},
+	)
+
+	return packageNames, nil
+}
+
+// importPathToPackageName guesses package name from import path without alias, as goimports does
+func (*ModelGenerator) importPathToPackageName(importPath string) string {
+	packageName := path.Base(importPath)
+
+	if strings.HasPrefix(packageName, "v") {
+		if _, err := strconv.Atoi(packageName[1:]); err == nil {
+			if directory := path.Dir(importPath); directory != "." {
+				packageName = path.Base(directory)
+			}
+		}
+	}
+
+	packageName = strings.TrimPrefix(packageName, "go-")
+
+	identifierEnd := strings.IndexFunc(
+		packageName, func(letter rune) bool {
+			return !(letter == '_' || unicode.IsLetter(letter) || unicode.IsDigit(letter))
+		},
+	)
+	if identifierEnd >= 0 {
+		packageName = packageName[:identifierEnd]
+	}
+
+	return packageName
+}
+
+// findDTOStructName returns first structure, that is not embedded into other structures of DTO file
+func (g *ModelGenerator) findDTOStructName(structTypeNames []string, structTypes map[string]*ast.StructType) string {
+	embeddedStructNames := make(map[string]struct{})
+	for _, astStruct := range structTypes {
+		for _, field := range astStruct.Fields.List {
+			if len(field.Names) == 0 {
+				embeddedStructNames[g.embeddedTypeName(field.Type)] = struct{}{}
+			}
+		}
+	}
+
+	for _, structTypeName := range structTypeNames {
+		if _, ok := embeddedStructNames[structTypeName]; !ok {
+			return structTypeName
+		}
+	}
+
+	return ""
+}
+
+// collectFields returns exported fields of DTO structure. Grouped fields are expanded, structures from DTO file
+// embedded into DTO are flattened and other embedded types are kept embedded in model.
+func (g *ModelGenerator) collectFields(
+	dtoFileContents string,
+	astStruct *ast.StructType,
+	structTypes map[string]*ast.StructType,
+	structName string,
+	visitedStructNames map[string]struct{},
+) []ModelField {
+	var modelFields []ModelField
+	var flattenedStructs []*ast.StructType
+	for _, field := range astStruct.Fields.List {
+		fieldType := dtoFileContents[(field.Type.Pos() - 1):(field.Type.End() - 1)]
+
+		if len(field.Names) == 0 {
+			embeddedName := g.embeddedTypeName(field.Type)
+
+			if embeddedStruct, ok := structTypes[embeddedName]; ok {
+				if _, ok := visitedStructNames[embeddedName]; !ok {
+					visitedStructNames[embeddedName] = struct{}{}
+					flattenedStructs = append(flattenedStructs, embeddedStruct)
+				}
+
+				continue
+			}
+
+			if !ast.IsExported(embeddedName) {
+				continue
+			}
+
+			modelFields = append(
+				modelFields, ModelField{
+					Name:       embeddedName,
+					Type:       fieldType,
+					StructName: structName,
+					IsEmbedded: true,
+				},
+			)
+
+			continue
+		}
+
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+
+			modelFields = append(
+				modelFields, ModelField{
+					Name:       fieldName.Name,
+					Type:       fieldType,
+					Column:     g.fieldColumn(field, fieldName.Name),
+					StructName: structName,
+				},
+			)
+		}
+	}
+
+	fieldNames := make(map[string]struct{})
+	for _, field := range modelFields {
+		fieldNames[field.Name] = struct{}{}
+	}
+
+	// fields of outer structure shadow fields of embedded one, as in Go selectors
+	for _, flattenedStruct := range flattenedStructs {
+		embeddedFields := g.collectFields(dtoFileContents, flattenedStruct, structTypes, structName, visitedStructNames)
+		for _, field := range embeddedFields {
+			if _, ok := fieldNames[field.Name]; ok {
+				continue
+			}
+
+			modelFields = append(modelFields, field)
+			fieldNames[field.Name] = struct{}{}
+		}
+	}
+
+	return modelFields
+}
+
+// fieldColumn returns database column name from "db" tag of DTO field, or field name if tag is not set
+func (*ModelGenerator) fieldColumn(field *ast.Field, fieldName string) string {
+	if field.Tag == nil {
+		return fieldName
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return fieldName
+	}
+
+	column := strings.Split(reflect.StructTag(tag).Get("db"), ",")[0]
+	if column == "" {
+		return fieldName
+	}
+
+	return column
+}
+
+// embeddedTypeName returns name of embedded field, such as "Time" for "*time.Time"
+func (g *ModelGenerator) embeddedTypeName(fieldType ast.Expr) string {
+	switch typeExpression := fieldType.(type) {
+	case *ast.Ident:
+		return typeExpression.Name
+	case *ast.StarExpr:
+		return g.embeddedTypeName(typeExpression.X)
+	case *ast.SelectorExpr:
+		return typeExpression.Sel.Name
+	case *ast.IndexExpr:
+		return g.embeddedTypeName(typeExpression.X)
+	case *ast.IndexListExpr:
+		return g.embeddedTypeName(typeExpression.X)
+	}
+
+	return ""
+}
+
+// findModelDeclarations returns type and function declarations of model file, which could be replaced by generator
+func (g *ModelGenerator) findModelDeclarations(
+	fileSet *token.FileSet,
+	file *ast.File,
+	contents string,
+) []modelDeclaration {
+	offset := func(position token.Pos) int {
+		return fileSet.Position(position).Offset
+	}
+
+	var declarations []modelDeclaration
+	for _, declaration := range file.Decls {
+		start := declaration.Pos()
+		switch typedDeclaration := declaration.(type) {
+		case *ast.GenDecl:
+			if typedDeclaration.Tok != token.TYPE {
+				continue
+			}
+
+			if typedDeclaration.Doc != nil {
+				start = typedDeclaration.Doc.Pos()
+			}
+
+			for _, spec := range typedDeclaration.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				declarations = append(
+					declarations, modelDeclaration{
+						Key:       "type " + typeSpec.Name.Name,
+						Name:      typeSpec.Name.Name,
+						Start:     offset(start),
+						End:       offset(typedDeclaration.End()),
+						BodyStart: offset(typeSpec.Type.Pos()),
+						BodyEnd:   offset(typeSpec.Type.End()),
+						Body:      contents[offset(typeSpec.Type.Pos()):offset(typeSpec.Type.End())],
+						Text:      contents[offset(typedDeclaration.Pos()):offset(typedDeclaration.End())],
+					},
+				)
+			}
+		case *ast.FuncDecl:
+			if typedDeclaration.Doc != nil {
+				start = typedDeclaration.Doc.Pos()
+			}
+
+			key := "func " + typedDeclaration.Name.Name
+			if typedDeclaration.Recv != nil && len(typedDeclaration.Recv.List) > 0 {
+				key = g.methodKey(g.embeddedTypeName(typedDeclaration.Recv.List[0].Type), typedDeclaration.Name.Name)
 			}
+
+			text := contents[offset(typedDeclaration.Pos()):offset(typedDeclaration.End())]
+			declarations = append(
+				declarations, modelDeclaration{
+					Key:       key,
+					Name:      typedDeclaration.Name.Name,
+					Start:     offset(start),
+					End:       offset(typedDeclaration.End()),
+					BodyStart: offset(typedDeclaration.Pos()),
+					BodyEnd:   offset(typedDeclaration.End()),
+					Body:      text,
+					Text:      text,
+				},
+			)
+		}
+	}
+
+	return declarations
+}
+
+// findOwnedMethodKeys returns keys of methods, previously generated for fields of existing model structure
+func (g *ModelGenerator) findOwnedMethodKeys(modelFile *ast.File, structName string) map[string]struct{} {
+	ownedMethodKeys := make(map[string]struct{})
+	ast.Inspect(
+		modelFile, func(astNode ast.Node) bool {
+			typeSpec, ok := astNode.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+
+			astStruct, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || typeSpec.Name.Name != structName {
+				return false
+			}
+
+			for _, field := range astStruct.Fields.List {
+				for _, fieldName := range field.Names {
+					methodName := StringCaseConverter{}.SnakeCaseToCamelCase(fieldName.Name)
+					ownedMethodKeys[g.methodKey(structName, methodName)] = struct{}{}
+					ownedMethodKeys[g.methodKey(structName, "Set"+methodName)] = struct{}{}
+					ownedMethodKeys[g.methodKey(structName, "With"+methodName)] = struct{}{}
+				}
+			}
+
+			return false
+		},
+	)
+
+	return ownedMethodKeys
+}
+
+func (*ModelGenerator) methodKey(receiverTypeName string, methodName string) string {
+	return fmt.Sprintf("method %s.%s", receiverTypeName, methodName)
+}
+
+// mergeImports replaces import declarations of contents with single declaration of used imports. Imports of
+// contents are preferred, and missing generated imports are added.
+func (g *ModelGenerator) mergeImports(
+	contents string,
+	generatedImports []*ast.ImportSpec,
+	generatedContents string,
+	generatedFileSet *token.FileSet,
+) (string, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "merged.go", contents, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("merged model parsing error: %w", err)
+	}
+
+	offset := func(position token.Pos) int {
+		return fileSet.Position(position).Offset
+	}
+
+	usedPackageNames := make(map[string]struct{})
+	ast.Inspect(
+		file, func(astNode ast.Node) bool {
+			selector, ok := astNode.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if packageIdent, ok := selector.X.(*ast.Ident); ok && packageIdent.Obj == nil {
+				usedPackageNames[packageIdent.Name] = struct{}{}
+			}
+
+			return true
+		},
+	)
+
+	importedPaths := make(map[string]struct{})
+	var importLines []string
+	addImport := func(importSpec *ast.ImportSpec, specText string) {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			return
+		}
+
+		if _, ok := importedPaths[importPath]; ok {
+			return
 		}
+
+		packageName := g.importPathToPackageName(importPath)
+		if importSpec.Name != nil {
+			packageName = importSpec.Name.Name
+		}
+
+		_, isUsed := usedPackageNames[packageName]
+		if !isUsed && packageName != "_" && packageName != "." {
+			return
+		}
+
+		importLines = append(importLines, specText)
+		importedPaths[importPath] = struct{}{}
+	}
+
+	for _, importSpec := range file.Imports {
+		addImport(importSpec, contents[offset(importSpec.Pos()):offset(importSpec.End())])
+	}
+
+	for _, importSpec := range generatedImports {
+		start := generatedFileSet.Position(importSpec.Pos()).Offset
+		end := generatedFileSet.Position(importSpec.End()).Offset
+		addImport(importSpec, generatedContents[start:end])
+	}
+
+	var importDeclaration string
+	if len(importLines) > 0 {
+		importDeclaration = fmt.Sprintf("import (\n\t%s\n)", strings.Join(importLines, "\n\t"))
+	}
+
+	var replacements []textReplacement
+	for _, declaration := range file.Decls {
+		genericDeclaration, ok := declaration.(*ast.GenDecl)
+		if !ok || genericDeclaration.Tok != token.IMPORT {
+			continue
+		}
+
+		replacements = append(
+			replacements, textReplacement{
+				Start: offset(genericDeclaration.Pos()),
+				End:   offset(genericDeclaration.End()),
+			},
+		)
+	}
+
+	if len(replacements) > 0 {
+		replacements[0].Text = importDeclaration
+	} else if importDeclaration != "" {
+		packageEnd := offset(file.Name.End())
+		replacements = append(
+			replacements, textReplacement{
+				Start: packageEnd,
+				End:   packageEnd,
+				Text:  "\n\n" + importDeclaration,
+			},
+		)
+	}
+
+	return g.replaceText(contents, replacements), nil
+}
+
+// replaceText applies non-overlapping replacements to text
+func (*ModelGenerator) replaceText(text string, replacements []textReplacement) string {
+	sort.SliceStable(
+		replacements, func(i, j int) bool {
+			return replacements[i].Start < replacements[j].Start
+		},
+	)
+
+	var result strings.Builder
+	lastEnd := 0
+	for _, replacement := range replacements {
+		result.WriteString(text[lastEnd:replacement.Start])
+		result.WriteString(replacement.Text)
+		lastEnd = replacement.End
 	}
+	result.WriteString(text[lastEnd:])
 
-	return imports
+	return result.String()
 }
 
 func (*ModelGenerator) removeDTOFromStructName(name string) string {
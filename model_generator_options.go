@@ -0,0 +1,82 @@
+package gorep
+
+import (
+	"io/fs"
+	"text/template"
+)
+
+// NullableUnwrapping defines how "sql.Null*" DTO fields are represented in model
+type NullableUnwrapping int
+
+const (
+	// NullableUnwrappingNone keeps "sql.Null*" types in model
+	NullableUnwrappingNone NullableUnwrapping = iota
+	// NullableUnwrappingPointer represents "sql.Null*" fields as pointers, nil for NULL values
+	NullableUnwrappingPointer
+	// NullableUnwrappingZeroValue represents "sql.Null*" fields as values, zero value for NULL values.
+	// Zero values are mapped back to NULL values.
+	NullableUnwrappingZeroValue
+)
+
+type ModelGeneratorOption func(generator *ModelGenerator)
+
+// WithModelMappers enables generation of New<Model>FromDTO() constructor and ToDTO() method
+func WithModelMappers() ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.withMappers = true
+	}
+}
+
+// WithModelNullableUnwrapping sets representation of "sql.Null*" DTO fields in model
+func WithModelNullableUnwrapping(nullableUnwrapping NullableUnwrapping) ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.nullableUnwrapping = nullableUnwrapping
+	}
+}
+
+// WithModelSetters enables generation of Set<Field>() methods, changing model fields
+func WithModelSetters() ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.withSetters = true
+	}
+}
+
+// WithModelCopyMethods enables generation of With<Field>() methods, returning model copy with changed field
+func WithModelCopyMethods() ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.withCopyMethods = true
+	}
+}
+
+// WithModelChangeTracking enables tracking of fields, changed by Set<Field>() and With<Field>() methods.
+// Model gets ChangedColumns() method, returning database columns of changed fields for repository UpdateColumns()
+// method, and ResetChanges() method.
+func WithModelChangeTracking() ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.withChangeTracking = true
+	}
+}
+
+// WithModelTemplate replaces model template with template contents
+func WithModelTemplate(templateContents string) ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.templateModel = templateContents
+	}
+}
+
+// WithModelTemplateFS replaces model template with template files of file system, matched by patterns.
+// File system must contain "model.template" file, other matched files could be used as associated templates.
+// If no patterns are set, only "model.template" file is parsed.
+func WithModelTemplateFS(templateFileSystem fs.FS, templatePatterns ...string) ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.templateFileSystem = templateFileSystem
+		generator.templatePatterns = templatePatterns
+	}
+}
+
+// WithModelTemplateFunctions adds custom functions to model template, overriding default functions with same names
+func WithModelTemplateFunctions(templateFunctions template.FuncMap) ModelGeneratorOption {
+	return func(generator *ModelGenerator) {
+		generator.templateFunctions = templateFunctions
+	}
+}
@@ -1,7 +1,11 @@
 package gorep
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"text/template"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,12 +20,28 @@ func TestModelGenerator_Generate(t *testing.T) {
 		fileNameWithoutStruct       = "test_data/dto_without_structure.test"
 		fileNameWithoutStructFields = "test_data/dto_without_struct_fields.test"
 		fileNameDto                 = "test_data/test_dto.go"
+		fileNameDtoWithImports      = "test_data/dto_with_imports.test"
+		fileNameDtoUnknownImport    = "test_data/dto_with_unknown_import.test"
+		fileNameDtoEmbeddedFields   = "test_data/dto_with_embedded_fields.test"
 		modelFileContents           = "test_data/test_model.golden"
+		modelWithImportsContents    = "test_data/test_model_with_imports.golden"
+		modelEmbeddedFieldsContents = "test_data/test_model_with_embedded_fields.golden"
+		fileNameDtoNullableFields   = "test_data/dto_with_nullable_fields.test"
+		modelWithMappersContents    = "test_data/test_model_with_mappers.golden"
+		modelPointerContents        = "test_data/test_model_with_pointer_unwrapping.golden"
+		modelZeroValueContents      = "test_data/test_model_with_zero_value_unwrapping.golden"
+		modelWithSettersContents    = "test_data/test_model_with_setters.golden"
+		modelChangeTrackingContents = "test_data/test_model_with_change_tracking.golden"
+		fileNameDtoKeywordFields    = "test_data/dto_with_keyword_fields.test"
+		modelKeywordFieldsContents  = "test_data/test_model_with_keyword_fields.golden"
+		fileNameInMemoryDto         = "test_data/in_memory/users_dto.go"
+		modelInMemoryContents       = "test_data/in_memory/users_model.go"
 	)
 	tests := []struct {
 		name          string
 		fileContents  string
 		packageName   string
+		options       []ModelGeneratorOption
 		expected      string
 		expectedError string
 	}{
@@ -32,6 +52,96 @@ func TestModelGenerator_Generate(t *testing.T) {
 			expected:      test_tools.GetFileContents(modelFileContents),
 			expectedError: "",
 		},
+		{
+			name:          "DTO with imported field types, must return model with DTO file imports and aliases",
+			fileContents:  test_tools.GetFileContents(fileNameDtoWithImports),
+			packageName:   packageName,
+			expected:      test_tools.GetFileContents(modelWithImportsContents),
+			expectedError: "",
+		},
+		{
+			name:          "DTO field type package is not imported, must return error",
+			fileContents:  test_tools.GetFileContents(fileNameDtoUnknownImport),
+			packageName:   packageName,
+			expected:      "",
+			expectedError: "package \"uuid\" of field \"Id\" is not imported in DTO file",
+		},
+		{
+			name:          "DTO with embedded, grouped and anonymous structure fields, must return model with expanded fields",
+			fileContents:  test_tools.GetFileContents(fileNameDtoEmbeddedFields),
+			packageName:   packageName,
+			expected:      test_tools.GetFileContents(modelEmbeddedFieldsContents),
+			expectedError: "",
+		},
+		{
+			name:          "mappers option, must return model with DTO mapping functions",
+			fileContents:  test_tools.GetFileContents(fileNameDto),
+			packageName:   packageName,
+			options:       []ModelGeneratorOption{WithModelMappers()},
+			expected:      test_tools.GetFileContents(modelWithMappersContents),
+			expectedError: "",
+		},
+		{
+			name:         "mappers and pointer unwrapping options, must return model with nullable fields as pointers",
+			fileContents: test_tools.GetFileContents(fileNameDtoNullableFields),
+			packageName:  packageName,
+			options: []ModelGeneratorOption{
+				WithModelMappers(),
+				WithModelNullableUnwrapping(NullableUnwrappingPointer),
+			},
+			expected:      test_tools.GetFileContents(modelPointerContents),
+			expectedError: "",
+		},
+		{
+			name:         "mappers and zero value unwrapping options, must return model with nullable fields as values",
+			fileContents: test_tools.GetFileContents(fileNameDtoNullableFields),
+			packageName:  packageName,
+			options: []ModelGeneratorOption{
+				WithModelMappers(),
+				WithModelNullableUnwrapping(NullableUnwrappingZeroValue),
+			},
+			expected:      test_tools.GetFileContents(modelZeroValueContents),
+			expectedError: "",
+		},
+		{
+			name:          "setters and copy methods options, must return model with Set and With methods",
+			fileContents:  test_tools.GetFileContents(fileNameDto),
+			packageName:   packageName,
+			options:       []ModelGeneratorOption{WithModelSetters(), WithModelCopyMethods()},
+			expected:      test_tools.GetFileContents(modelWithSettersContents),
+			expectedError: "",
+		},
+		{
+			name:         "change tracking option, must return model tracking changed columns",
+			fileContents: test_tools.GetFileContents(fileNameDto),
+			packageName:  packageName,
+			options: []ModelGeneratorOption{
+				WithModelSetters(),
+				WithModelCopyMethods(),
+				WithModelChangeTracking(),
+			},
+			expected:      test_tools.GetFileContents(modelChangeTrackingContents),
+			expectedError: "",
+		},
+		{
+			name:         "DTO of in-memory repository tests, must return model tracking changed columns of users table",
+			fileContents: test_tools.GetFileContents(fileNameInMemoryDto),
+			packageName:  "in_memory",
+			options: []ModelGeneratorOption{
+				WithModelMappers(),
+				WithModelSetters(),
+				WithModelChangeTracking(),
+			},
+			expected:      test_tools.GetFileContents(modelInMemoryContents),
+			expectedError: "",
+		},
+		{
+			name:          "DTO with fields named as Go keywords, must return model with valid identifiers",
+			fileContents:  test_tools.GetFileContents(fileNameDtoKeywordFields),
+			packageName:   packageName,
+			expected:      test_tools.GetFileContents(modelKeywordFieldsContents),
+			expectedError: "",
+		},
 		{
 			name:          "package name is empty, must return error",
 			fileContents:  test_tools.GetFileContents(modelFileContents),
@@ -71,7 +181,7 @@ func TestModelGenerator_Generate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(
 			tt.name, func(t *testing.T) {
-				generator := NewModelGenerator()
+				generator := NewModelGenerator(tt.options...)
 
 				result, err := generator.Generate(tt.packageName, tt.fileContents)
 
@@ -86,6 +196,77 @@ func TestModelGenerator_Generate(t *testing.T) {
 	}
 }
 
+func TestModelGenerator_Regenerate(t *testing.T) {
+	const (
+		packageName               = "package_name"
+		fileNameDto               = "test_data/test_dto.go"
+		fileNameNotGolang         = "test_data/non_golang_file.txt"
+		fileNameModelWithMethods  = "test_data/model_with_methods.test"
+		modelFileContents         = "test_data/test_model.golden"
+		regeneratedModelContents  = "test_data/test_model_regenerated.golden"
+		regeneratedGeneratedModel = "test_data/test_model_regenerated_from_generated.golden"
+	)
+	tests := []struct {
+		name              string
+		dtoFileContents   string
+		modelFileContents string
+		expected          string
+		expectedError     string
+	}{
+		{
+			name:              "model file with hand-written methods, must replace generated parts and keep other declarations",
+			dtoFileContents:   test_tools.GetFileContents(fileNameDto),
+			modelFileContents: test_tools.GetFileContents(fileNameModelWithMethods),
+			expected:          test_tools.GetFileContents(regeneratedModelContents),
+			expectedError:     "",
+		},
+		{
+			name:              "generated model file, must return formatted generated model",
+			dtoFileContents:   test_tools.GetFileContents(fileNameDto),
+			modelFileContents: test_tools.GetFileContents(modelFileContents),
+			expected:          test_tools.GetFileContents(regeneratedGeneratedModel),
+			expectedError:     "",
+		},
+		{
+			name:              "model file contents is empty, must return generated model",
+			dtoFileContents:   test_tools.GetFileContents(fileNameDto),
+			modelFileContents: "",
+			expected:          test_tools.GetFileContents(modelFileContents),
+			expectedError:     "",
+		},
+		{
+			name:              "model file is not golang file, must return error",
+			dtoFileContents:   test_tools.GetFileContents(fileNameDto),
+			modelFileContents: test_tools.GetFileContents(fileNameNotGolang),
+			expected:          "",
+			expectedError:     "model file contents parsing error",
+		},
+		{
+			name:              "dto contents is empty, must return error",
+			dtoFileContents:   "",
+			modelFileContents: test_tools.GetFileContents(fileNameModelWithMethods),
+			expected:          "",
+			expectedError:     "dto file contents must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewModelGenerator()
+
+				result, err := generator.Regenerate(packageName, tt.dtoFileContents, tt.modelFileContents)
+
+				if tt.expectedError == "" {
+					assert.Nil(t, err, err)
+				} else {
+					assert.ErrorContains(t, err, tt.expectedError)
+				}
+				assert.Equal(t, tt.expected, result)
+			},
+		)
+	}
+}
+
 func TestModelGenerator_Generate_InvalidTemplate(t *testing.T) {
 	mockController := gomock.NewController(t)
 	defer mockController.Finish()
@@ -126,3 +307,90 @@ func TestModelGenerator_Generate_InvalidTemplate(t *testing.T) {
 		},
 	)
 }
+
+func TestModelGenerator_Generate_CustomTemplate(t *testing.T) {
+	const (
+		packageName = "package_name"
+		fileNameDto = "test_data/test_dto.go"
+	)
+	templateFileSystem := fstest.MapFS{
+		"model.template": &fstest.MapFile{
+			Data: []byte(`{{ template "header.template" . }}type {{ .StructName }} {{ .DTOStructName }}`),
+		},
+		"header.template": &fstest.MapFile{
+			Data: []byte("package {{ .PackageName }}\n\n"),
+		},
+	}
+	tests := []struct {
+		name          string
+		options       []ModelGeneratorOption
+		expected      string
+		expectedError string
+	}{
+		{
+			name:          "custom template contents, must return model generated with custom template",
+			options:       []ModelGeneratorOption{WithModelTemplate(`{{ .StructName }}{{ range .Fields }} {{ .Name }}{{ end }}`)},
+			expected:      "Test Id Time Value",
+			expectedError: "",
+		},
+		{
+			name:          "custom template file system with associated template, must return model generated with templates",
+			options:       []ModelGeneratorOption{WithModelTemplateFS(templateFileSystem, "*.template")},
+			expected:      "package package_name\n\ntype Test TestDTO",
+			expectedError: "",
+		},
+		{
+			name: "custom template functions, must return model generated with custom and default functions",
+			options: []ModelGeneratorOption{
+				WithModelTemplate(`{{ .StructName | Shout }} {{ .StructName | Plural }}`),
+				WithModelTemplateFunctions(template.FuncMap{"Shout": strings.ToUpper}),
+			},
+			expected:      "TEST Tests",
+			expectedError: "",
+		},
+		{
+			name:          "custom template file system without model template, must return error",
+			options:       []ModelGeneratorOption{WithModelTemplateFS(templateFileSystem, "header.template")},
+			expected:      "",
+			expectedError: "\"model.template\" is an incomplete or empty template",
+		},
+		{
+			name:          "custom template file system without matching files, must return error",
+			options:       []ModelGeneratorOption{WithModelTemplateFS(fstest.MapFS{})},
+			expected:      "",
+			expectedError: "pattern matches no files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewModelGenerator(tt.options...)
+
+				result, err := generator.Generate(packageName, test_tools.GetFileContents(fileNameDto))
+
+				if tt.expectedError == "" {
+					assert.Nil(t, err, err)
+				} else {
+					assert.ErrorContains(t, err, tt.expectedError)
+				}
+				assert.Equal(t, tt.expected, result)
+			},
+		)
+	}
+}
+
+func TestModelGenerator_GenerateContext(t *testing.T) {
+	const (
+		packageName = "package_name"
+		fileNameDto = "test_data/test_dto.go"
+	)
+	fileContents := test_tools.GetFileContents(fileNameDto)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	generator := NewModelGenerator()
+
+	result, err := generator.GenerateContext(ctx, packageName, fileContents)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,9 @@
+package gorep
+
+// ModelImport is package import, passed to model template
+type ModelImport struct {
+	// Alias is import alias, empty if package is imported without alias
+	Alias string
+	// Path is package import path
+	Path string
+}
@@ -0,0 +1,25 @@
+package gorep
+
+// ModelTemplateData is data, passed to model template
+type ModelTemplateData struct {
+	// PackageName is package name of generated model
+	PackageName string
+	// StructName is model structure name, DTO structure name without "DTO"
+	StructName string
+	// DTOStructName is DTO structure name
+	DTOStructName string
+	// Fields are DTO structure fields, sorted by name
+	Fields []ModelField
+	// Imports are packages, used by field types
+	Imports []ModelImport
+	// TrackedColumns are database columns of not embedded fields, ordered as Fields
+	TrackedColumns []string
+	// WithMappers is true if WithModelMappers option is set
+	WithMappers bool
+	// WithSetters is true if WithModelSetters option is set
+	WithSetters bool
+	// WithCopyMethods is true if WithModelCopyMethods option is set
+	WithCopyMethods bool
+	// WithChangeTracking is true if WithModelChangeTracking option is set
+	WithChangeTracking bool
+}
@@ -0,0 +1,20 @@
+package gorep
+
+// nullableType describes "sql.Null*" type with its value type, value field name and format of condition,
+// checking that value is not zero
+type nullableType struct {
+	Type          string
+	ValueField    string
+	NotZeroFormat string
+}
+
+var nullableTypes = map[string]nullableType{
+	"sql.NullBool":    {Type: "bool", ValueField: "Bool", NotZeroFormat: "%s"},
+	"sql.NullByte":    {Type: "byte", ValueField: "Byte", NotZeroFormat: "%s != 0"},
+	"sql.NullFloat64": {Type: "float64", ValueField: "Float64", NotZeroFormat: "%s != 0"},
+	"sql.NullInt16":   {Type: "int16", ValueField: "Int16", NotZeroFormat: "%s != 0"},
+	"sql.NullInt32":   {Type: "int32", ValueField: "Int32", NotZeroFormat: "%s != 0"},
+	"sql.NullInt64":   {Type: "int64", ValueField: "Int64", NotZeroFormat: "%s != 0"},
+	"sql.NullString":  {Type: "string", ValueField: "String", NotZeroFormat: `%s != ""`},
+	"sql.NullTime":    {Type: "time.Time", ValueField: "Time", NotZeroFormat: "!%s.IsZero()"},
+}
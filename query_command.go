@@ -0,0 +1,28 @@
+package gorep
+
+// QueryCommand is command of named query, defining how query result is returned
+type QueryCommand string
+
+const (
+	// QueryCommandOne returns single result row
+	QueryCommandOne QueryCommand = ":one"
+	// QueryCommandMany returns slice of result rows
+	QueryCommandMany QueryCommand = ":many"
+	// QueryCommandExec executes query without result rows
+	QueryCommandExec QueryCommand = ":exec"
+)
+
+// Valid returns true for known query commands
+func (c QueryCommand) Valid() bool {
+	switch c {
+	case QueryCommandOne, QueryCommandMany, QueryCommandExec:
+		return true
+	}
+
+	return false
+}
+
+// ReturnsRows returns true for commands, which return result rows
+func (c QueryCommand) ReturnsRows() bool {
+	return c == QueryCommandOne || c == QueryCommandMany
+}
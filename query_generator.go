@@ -0,0 +1,435 @@
+package gorep
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	_ "embed"
+	"errors"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"text/template"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+//go:embed query.template
+var templateQueryFile string
+
+const (
+	// pqPackagePath is import path of database driver, which prepared statements are described
+	pqPackagePath = "github.com/lib/pq"
+	// queryStatementNameFormat is format of prepared statement names, numbered by queryStatementSequence
+	queryStatementNameFormat = "gorep_query_%d"
+)
+
+// queryStatementSequence numbers prepared statements. Statement, prepared in failed transaction, could not be
+// deallocated and stays in session of pooled connection, so each statement gets unique name.
+var queryStatementSequence uint64
+
+// QueryGenerator generates typed functions of named SQL queries. Query parameters and result columns are described
+// by database, so queries could join tables and aggregate rows, not mapped to single DTO.
+type QueryGenerator struct {
+	database           ConnectionDatabase
+	dtoGenerator       *DtoGenerator
+	templateQuery      string
+	templateFileSystem fs.FS
+	templatePatterns   []string
+	templateFunctions  template.FuncMap
+}
+
+func NewQueryGenerator(database ConnectionDatabase, options ...QueryGeneratorOption) *QueryGenerator {
+	generator := &QueryGenerator{
+		database:      database,
+		dtoGenerator:  NewDtoGenerator(nil),
+		templateQuery: templateQueryFile,
+	}
+	for _, option := range options {
+		option(generator)
+	}
+
+	return generator
+}
+
+// Generate generates query functions and result rows of SQL file contents as file content string. Each query
+// starts with "-- name: <Name> <command>" comment, where command is ":one", ":many" or ":exec".
+func (g *QueryGenerator) Generate(packageName string, sqlFileContents string) (string, error) {
+	return g.GenerateContext(context.Background(), packageName, sqlFileContents)
+}
+
+// GenerateContext generates query functions and result rows of SQL file contents as file content string.
+// Database queries are cancelled with context.
+func (g *QueryGenerator) GenerateContext(
+	ctx context.Context,
+	packageName string,
+	sqlFileContents string,
+) (string, error) {
+	if len(packageName) == 0 {
+		return "", errors.New("package name must not be empty")
+	}
+
+	templator, err := parseTemplate(
+		"query.template",
+		g.templateQuery,
+		g.templateFileSystem,
+		g.templatePatterns,
+		mergeTemplateFunctions(g.templateFunctions),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	queries, err := g.parseQueries(sqlFileContents)
+	if err != nil {
+		return "", err
+	}
+
+	err = g.describeQueries(ctx, queries)
+	if err != nil {
+		return "", err
+	}
+
+	data := QueryTemplateData{
+		PackageName: packageName,
+		Imports:     g.createImports(queries),
+		Queries:     queries,
+	}
+
+	var buffer bytes.Buffer
+	err = templator.Execute(&buffer, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// parseQueries splits SQL file contents into named queries. Query text is placed between its name comment and
+// the next one, trailing semicolon is removed.
+func (g *QueryGenerator) parseQueries(sqlFileContents string) ([]DatabaseQuery, error) {
+	var queries []DatabaseQuery
+	var queryLines []string
+	names := make(map[string]struct{})
+
+	completeQuery := func() error {
+		if len(queries) == 0 {
+			if strings.TrimSpace(strings.Join(queryLines, "\n")) != "" {
+				return errors.New("query must start with \"-- name: <Name> <command>\" comment")
+			}
+
+			return nil
+		}
+
+		query := &queries[len(queries)-1]
+		query.Query = strings.TrimSuffix(strings.TrimSpace(strings.Join(queryLines, "\n")), ";")
+		if query.Query == "" {
+			return fmt.Errorf("query \"%s\" must not be empty", query.Name)
+		}
+
+		if strings.Contains(query.Query, "`") {
+			return fmt.Errorf("query \"%s\" must not contain backquote", query.Name)
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(sqlFileContents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		annotation := strings.TrimSpace(line)
+		if !strings.HasPrefix(annotation, "-- name:") {
+			queryLines = append(queryLines, line)
+			continue
+		}
+
+		err := completeQuery()
+		if err != nil {
+			return nil, err
+		}
+
+		queryLines = nil
+		annotationParts := strings.Fields(strings.TrimPrefix(annotation, "-- name:"))
+		if len(annotationParts) != 2 {
+			return nil, fmt.Errorf("invalid query annotation \"%s\"", annotation)
+		}
+
+		name, command := annotationParts[0], QueryCommand(annotationParts[1])
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("query name \"%s\" must be valid Go identifier", name)
+		}
+
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("query name \"%s\" is duplicated", name)
+		}
+
+		if !command.Valid() {
+			return nil, fmt.Errorf("unknown command \"%s\" of query \"%s\"", command, name)
+		}
+
+		names[name] = struct{}{}
+		queries = append(queries, DatabaseQuery{Name: name, Command: command})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = completeQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(queries) == 0 {
+		return nil, errors.New("no named queries found")
+	}
+
+	return queries, nil
+}
+
+// describeQueries sets parameters and result columns of queries. Queries are described in transaction of dedicated
+// connection, as prepared statements are bound to session. Transaction is always rolled back.
+func (g *QueryGenerator) describeQueries(ctx context.Context, queries []DatabaseQuery) error {
+	connection, err := g.database.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	tx, err := connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range queries {
+		query := &queries[i]
+
+		query.Parameters, err = g.fetchParameters(ctx, tx, query.Query)
+		if err != nil {
+			return fmt.Errorf("query \"%s\": %w", query.Name, err)
+		}
+
+		if !query.Command.ReturnsRows() {
+			continue
+		}
+
+		query.Columns, err = g.fetchColumns(ctx, connection, tx, query.Query)
+		if err != nil {
+			return fmt.Errorf("query \"%s\": %w", query.Name, err)
+		}
+
+		if len(query.Columns) == 0 {
+			return fmt.Errorf("query \"%s\" with %s command must return columns", query.Name, query.Command)
+		}
+	}
+
+	return nil
+}
+
+// fetchParameters prepares query and returns types of its parameters, inferred by database
+func (g *QueryGenerator) fetchParameters(ctx context.Context, tx *sqlx.Tx, query string) ([]DatabaseField, error) {
+	statementName := fmt.Sprintf(queryStatementNameFormat, atomic.AddUint64(&queryStatementSequence, 1))
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("PREPARE %s AS %s", statementName, query))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT COALESCE(base_type.typname, pg_type.typname)
+		FROM pg_prepared_statements
+		CROSS JOIN LATERAL unnest(pg_prepared_statements.parameter_types)
+			WITH ORDINALITY AS parameters(type_oid, position_number)
+		JOIN pg_type ON pg_type.oid = parameters.type_oid::oid
+		LEFT JOIN pg_type base_type ON base_type.oid = pg_type.typbasetype
+		WHERE pg_prepared_statements.name = $1
+		ORDER BY parameters.position_number`,
+		statementName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parameters []DatabaseField
+	for rows.Next() {
+		var databaseTypeName string
+		err = rows.Scan(&databaseTypeName)
+		if err != nil {
+			return nil, err
+		}
+
+		parameters = append(
+			parameters, DatabaseField{
+				Name:         fmt.Sprintf("arg%d", len(parameters)+1),
+				Type:         g.dtoGenerator.mapDatabaseType(databaseTypeName),
+				DatabaseType: databaseTypeName,
+			},
+		)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DEALLOCATE "+statementName)
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+// fetchColumns returns result columns of query, described by database without query execution: query is prepared
+// by driver and columns are read from prepared statement description. Nullability of query expressions is unknown,
+// so all columns are nullable.
+func (g *QueryGenerator) fetchColumns(
+	ctx context.Context,
+	connection *sqlx.Conn,
+	tx *sqlx.Tx,
+	query string,
+) ([]DatabaseField, error) {
+	var columnNames []string
+	var columnTypeIds []int64
+	err := connection.Raw(
+		func(driverConnection interface{}) error {
+			preparer, ok := driverConnection.(driver.Conn)
+			if !ok {
+				return fmt.Errorf("database driver connection %T could not prepare statements", driverConnection)
+			}
+
+			statement, err := preparer.Prepare(query)
+			if err != nil {
+				return err
+			}
+			defer statement.Close()
+
+			columnNames, columnTypeIds, err = g.describeStatement(statement)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	typeNames, err := g.fetchTypeNames(ctx, tx, columnTypeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldNames := make(map[string]struct{})
+	var columns []DatabaseField
+	for i, columnName := range columnNames {
+		fieldName := goIdentifier(StringCaseConverter{}.SnakeCaseToCamelCase(columnName))
+		if _, ok := fieldNames[fieldName]; ok {
+			return nil, fmt.Errorf("column \"%s\" is duplicated, columns must have unique aliases", columnName)
+		}
+
+		fieldNames[fieldName] = struct{}{}
+		databaseTypeName := typeNames[columnTypeIds[i]]
+		columns = append(
+			columns, DatabaseField{
+				Name:         columnName,
+				Type:         g.dtoGenerator.mapNullableTypeName(g.dtoGenerator.mapDatabaseType(databaseTypeName)),
+				DatabaseType: databaseTypeName,
+				IsNullable:   true,
+			},
+		)
+	}
+
+	return columns, nil
+}
+
+// describeStatement returns result column names and type ids of prepared statement. Driver "github.com/lib/pq"
+// describes statement on prepare, but does not export description, so it is read from fields of its statement type.
+// Statements of other drivers and of driver versions with other statement fields are not supported.
+func (*QueryGenerator) describeStatement(statement driver.Stmt) ([]string, []int64, error) {
+	statementType := reflect.TypeOf(statement)
+	if statementType == nil || statementType.Kind() != reflect.Ptr ||
+		statementType.Elem().PkgPath() != pqPackagePath || statementType.Elem().Name() != "stmt" {
+		return nil, nil, fmt.Errorf(
+			"query columns could be described only with \"%s\" driver, statement %T given",
+			pqPackagePath,
+			statement,
+		)
+	}
+
+	unsupportedErr := fmt.Errorf(
+		"statement of \"%s\" driver has no column description, driver version is not supported",
+		pqPackagePath,
+	)
+	value := reflect.ValueOf(statement).Elem()
+	names := value.FieldByName("colNames")
+	types := value.FieldByName("colTyps")
+	if !names.IsValid() || names.Type() != reflect.TypeOf([]string(nil)) ||
+		!types.IsValid() || types.Kind() != reflect.Slice || types.Type().Elem().Kind() != reflect.Struct {
+		return nil, nil, unsupportedErr
+	}
+
+	typeIdField, ok := types.Type().Elem().FieldByName("OID")
+	if !ok || typeIdField.Type.Kind() != reflect.Uint32 || names.Len() != types.Len() {
+		return nil, nil, unsupportedErr
+	}
+
+	columnNames := make([]string, 0, names.Len())
+	columnTypeIds := make([]int64, 0, types.Len())
+	for i := 0; i < names.Len(); i++ {
+		columnNames = append(columnNames, names.Index(i).String())
+		columnTypeIds = append(columnTypeIds, int64(types.Index(i).FieldByIndex(typeIdField.Index).Uint()))
+	}
+
+	return columnNames, columnTypeIds, nil
+}
+
+// fetchTypeNames returns names of types by their ids. Domain types are replaced with their base types.
+func (*QueryGenerator) fetchTypeNames(ctx context.Context, tx *sqlx.Tx, typeIds []int64) (map[int64]string, error) {
+	typeNames := make(map[int64]string, len(typeIds))
+	if len(typeIds) == 0 {
+		return typeNames, nil
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT pg_type.oid, COALESCE(base_type.typname, pg_type.typname)
+		FROM pg_type
+		LEFT JOIN pg_type base_type ON base_type.oid = pg_type.typbasetype
+		WHERE pg_type.oid = ANY($1::oid[])`,
+		pq.Array(typeIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var typeId int64
+		var typeName string
+		err = rows.Scan(&typeId, &typeName)
+		if err != nil {
+			return nil, err
+		}
+
+		typeNames[typeId] = typeName
+	}
+
+	return typeNames, rows.Err()
+}
+
+func (g *QueryGenerator) createImports(queries []DatabaseQuery) []string {
+	imports := []string{"context", "github.com/jmoiron/sqlx"}
+	for _, query := range queries {
+		imports = g.dtoGenerator.appendImports(imports, g.dtoGenerator.createImports(query.Parameters)...)
+		imports = g.dtoGenerator.appendImports(imports, g.dtoGenerator.createImports(query.Columns)...)
+	}
+
+	return imports
+}
@@ -0,0 +1,32 @@
+package gorep
+
+import (
+	"io/fs"
+	"text/template"
+)
+
+type QueryGeneratorOption func(generator *QueryGenerator)
+
+// WithQueryTemplate replaces query template with template contents
+func WithQueryTemplate(templateContents string) QueryGeneratorOption {
+	return func(generator *QueryGenerator) {
+		generator.templateQuery = templateContents
+	}
+}
+
+// WithQueryTemplateFS replaces query template with template files of file system, matched by patterns.
+// File system must contain "query.template" file, other matched files could be used as associated templates.
+// If no patterns are set, only "query.template" file is parsed.
+func WithQueryTemplateFS(templateFileSystem fs.FS, templatePatterns ...string) QueryGeneratorOption {
+	return func(generator *QueryGenerator) {
+		generator.templateFileSystem = templateFileSystem
+		generator.templatePatterns = templatePatterns
+	}
+}
+
+// WithQueryTemplateFunctions adds custom functions to query template, overriding default functions with same names
+func WithQueryTemplateFunctions(templateFunctions template.FuncMap) QueryGeneratorOption {
+	return func(generator *QueryGenerator) {
+		generator.templateFunctions = templateFunctions
+	}
+}
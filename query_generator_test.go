@@ -0,0 +1,156 @@
+package gorep
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/golang/mock/gomock"
+
+	"github.com/vehsamrak/gorep/test_data"
+	"github.com/vehsamrak/gorep/test_tools"
+)
+
+func TestQueryGenerator_Generate_testDatabase(t *testing.T) {
+	const (
+		packageName           = "package_name"
+		usersTableName        = "users"
+		ordersTableName       = "orders"
+		queriesPath           = "test_data/queries.test"
+		testQueriesGoldenPath = "test_data/test_queries.golden"
+	)
+
+	dropTable(testDatabase, ordersTableName)
+	dropTable(testDatabase, usersTableName)
+	createTable(
+		testDatabase, usersTableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"email":      makeNotNullable(databaseFieldTypeVarchar),
+			"created_at": databaseFieldTypeTimestamp,
+		},
+	)
+	defer dropTable(testDatabase, usersTableName)
+	createTable(
+		testDatabase, ordersTableName, map[string]string{
+			"id":      databaseFieldTypeSerial + " PRIMARY KEY",
+			"user_id": makeNotNullable(databaseFieldTypeInt4) + " REFERENCES " + usersTableName + " (id)",
+		},
+	)
+	defer dropTable(testDatabase, ordersTableName)
+	expected := test_tools.GetFileContents(testQueriesGoldenPath)
+
+	generator := NewQueryGenerator(testDatabase)
+	result, err := generator.Generate(packageName, test_tools.GetFileContents(queriesPath))
+
+	if err != nil {
+		t.Errorf("Generate() returned error: %v", err)
+	}
+	if result != expected {
+		t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+	}
+}
+
+func TestQueryGenerator_Generate_mockDatabase(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	t.Run(
+		"database connection error, must return error", func(t *testing.T) {
+			mockDatabase := test_data.NewMockConnectionDatabase(mockController)
+			mockDatabase.EXPECT().Connx(gomock.Any()).Return(nil, errors.New("error"))
+			generator := NewQueryGenerator(mockDatabase)
+
+			result, err := generator.Generate("package_name", "-- name: GetUsers :many\nSELECT * FROM users")
+
+			if err == nil {
+				t.Errorf("Generate() must return error")
+			}
+			if result != "" {
+				t.Errorf("Generate() must return empty result, returned \"%s\"", result)
+			}
+		},
+	)
+
+	tests := []struct {
+		name                 string
+		sqlFileContents      string
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "query without name comment, must return error",
+			sqlFileContents:      "SELECT * FROM users",
+			expectedErrorMessage: "query must start with \"-- name: <Name> <command>\" comment",
+		},
+		{
+			name:                 "file without queries, must return error",
+			sqlFileContents:      "\n",
+			expectedErrorMessage: "no named queries found",
+		},
+		{
+			name:                 "query without command, must return error",
+			sqlFileContents:      "-- name: GetUsers\nSELECT * FROM users",
+			expectedErrorMessage: "invalid query annotation \"-- name: GetUsers\"",
+		},
+		{
+			name:                 "unknown command, must return error",
+			sqlFileContents:      "-- name: GetUsers :all\nSELECT * FROM users",
+			expectedErrorMessage: "unknown command \":all\" of query \"GetUsers\"",
+		},
+		{
+			name:                 "invalid query name, must return error",
+			sqlFileContents:      "-- name: get-users :many\nSELECT * FROM users",
+			expectedErrorMessage: "query name \"get-users\" must be valid Go identifier",
+		},
+		{
+			name:                 "duplicated query name, must return error",
+			sqlFileContents:      "-- name: GetUsers :many\nSELECT 1;\n-- name: GetUsers :many\nSELECT 2",
+			expectedErrorMessage: "query name \"GetUsers\" is duplicated",
+		},
+		{
+			name:                 "empty query, must return error",
+			sqlFileContents:      "-- name: GetUsers :many\n;\n",
+			expectedErrorMessage: "query \"GetUsers\" must not be empty",
+		},
+		{
+			name:                 "query with backquote, must return error",
+			sqlFileContents:      "-- name: GetUsers :many\nSELECT 1 AS `one`",
+			expectedErrorMessage: "query \"GetUsers\" must not contain backquote",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				mockDatabase := test_data.NewMockConnectionDatabase(mockController)
+				generator := NewQueryGenerator(mockDatabase)
+
+				_, err := generator.Generate("package_name", tt.sqlFileContents)
+
+				if err == nil || err.Error() != tt.expectedErrorMessage {
+					t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedErrorMessage, err)
+				}
+			},
+		)
+	}
+}
+
+// stmt is prepared statement of other database driver with the same fields as statement of "github.com/lib/pq"
+type stmt struct {
+	driver.Stmt
+	colNames []string
+	colTyps  []struct{ OID uint32 }
+}
+
+func TestQueryGenerator_describeStatement(t *testing.T) {
+	generator := NewQueryGenerator(nil)
+
+	columnNames, columnTypeIds, err := generator.describeStatement(&stmt{colNames: []string{"id"}})
+
+	expectedError := "query columns could be described only with \"github.com/lib/pq\" driver, statement *gorep.stmt given"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("describeStatement() must return error \"%s\", returned \"%v\"", expectedError, err)
+	}
+	if columnNames != nil || columnTypeIds != nil {
+		t.Errorf("describeStatement() must return no columns, returned %v, %v", columnNames, columnTypeIds)
+	}
+}
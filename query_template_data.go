@@ -0,0 +1,11 @@
+package gorep
+
+// QueryTemplateData is data, passed to query template
+type QueryTemplateData struct {
+	// PackageName is package name of generated queries
+	PackageName string
+	// Imports are import paths of packages, used by query functions and result rows
+	Imports []string
+	// Queries are named queries in SQL file order
+	Queries []DatabaseQuery
+}
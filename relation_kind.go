@@ -0,0 +1,18 @@
+package gorep
+
+// RelationKind is kind of database relation, DTO is generated for
+type RelationKind string
+
+const (
+	// RelationKindTable is table, including partitioned and foreign tables
+	RelationKindTable RelationKind = "table"
+	// RelationKindView is read-only view
+	RelationKindView RelationKind = "view"
+	// RelationKindMaterializedView is read-only materialized view, which could be refreshed
+	RelationKindMaterializedView RelationKind = "materialized view"
+)
+
+// IsReadOnly returns true if relation rows could not be inserted, updated or deleted
+func (k RelationKind) IsReadOnly() bool {
+	return k == RelationKindView || k == RelationKindMaterializedView
+}
@@ -0,0 +1,24 @@
+package gorep
+
+// RepositoryAudit is setting of audit columns by repository write methods, passed to repository template
+type RepositoryAudit struct {
+	// Fields are audit columns, sorted by name
+	Fields []DatabaseField
+	// ActorType is not nullable Go type of actor columns, empty if there are no actor columns
+	ActorType string
+	// Insert sets audit columns of inserted DTO
+	Insert *RepositoryAuditMethod
+	// Update sets audit columns of updated DTO, nil if there are no updated audit columns
+	Update *RepositoryAuditMethod
+}
+
+// RepositoryAuditMethod is method, setting audit columns of DTO
+type RepositoryAuditMethod struct {
+	// Assignments are Go statements, setting DTO fields. Statements use "now" variable with current time of
+	// repository clock and "actor" variable with actor of context.
+	Assignments []string
+	// IsClockUsed is true if statements use "now" variable
+	IsClockUsed bool
+	// IsActorUsed is true if statements use "actor" variable
+	IsActorUsed bool
+}
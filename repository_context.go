@@ -0,0 +1,29 @@
+package gorep
+
+import "context"
+
+type actorContextKey struct{}
+
+// ContextWithActor returns context with actor, which is set by generated repositories into audit columns, such as
+// "created_by". Actor type must be the same as not nullable Go type of audit columns, for example string or int64.
+func ContextWithActor(ctx context.Context, actor interface{}) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns actor of context, nil if actor is not set
+func ActorFromContext(ctx context.Context) interface{} {
+	return ctx.Value(actorContextKey{})
+}
+
+type tenantContextKey struct{}
+
+// ContextWithTenant returns context with tenant, which scopes queries of generated repositories with tenant column.
+// Tenant type must be the same as Go type of tenant column, for example string or int64.
+func ContextWithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns tenant of context, nil if tenant is not set
+func TenantFromContext(ctx context.Context) interface{} {
+	return ctx.Value(tenantContextKey{})
+}
@@ -0,0 +1,13 @@
+package gorep
+
+// RepositoryCopy is bulk insert method with "COPY" protocol, passed to repository template
+type RepositoryCopy struct {
+	// Schema is schema name of copied table
+	Schema string
+	// Table is copied table name without schema
+	Table string
+	// Columns are copied column names, the same as "db" tags of DTO fields
+	Columns []string
+	// Arguments are Go expressions of DTO fields, copied in columns order
+	Arguments []string
+}
@@ -0,0 +1,15 @@
+package gorep
+
+import "errors"
+
+var (
+	// ErrConcurrentModification is returned by generated Update() method of table with version column, if row was
+	// changed or deleted after it was read
+	ErrConcurrentModification = errors.New("concurrent modification of row")
+	// ErrActorNotSet is returned by generated write methods of table with actor audit columns, if actor of context
+	// is not set with ContextWithActor() or has other type
+	ErrActorNotSet = errors.New("actor is not set in context")
+	// ErrTenantNotSet is returned by generated methods of relation with tenant column, if tenant is not set with
+	// repository WithTenant() method or with ContextWithTenant(), or has other type
+	ErrTenantNotSet = errors.New("tenant is not set")
+)
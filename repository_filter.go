@@ -0,0 +1,42 @@
+package gorep
+
+// RepositoryFilter is filter of repository FindBy() method, passed to repository template
+type RepositoryFilter struct {
+	// StructName is name of filter struct, for example "UsersFilter"
+	StructName string
+	// OrderStructName is name of order struct, for example "UsersOrder"
+	OrderStructName string
+	// ColumnTypeName is name of column type with column constants, for example "UsersColumn"
+	ColumnTypeName string
+	// Fields are filter predicates of columns, except tenant and soft delete columns, sorted by column name
+	Fields []RepositoryFilterField
+	// SelectQuery is query without conditions, filter conditions, order and limit are added to it
+	SelectQuery string
+	// ScopeCondition is condition, added to filter conditions of all queries, such as soft delete condition. It is
+	// empty if there is no scope.
+	ScopeCondition string
+	// DefaultOrder is "ORDER BY" clause of primary key, used if order is not set, empty if there is no primary key
+	DefaultOrder string
+}
+
+// RepositoryFilterField is filter predicates of column
+type RepositoryFilterField struct {
+	// Field is filtered column
+	Field DatabaseField
+	// Name is Go name of column, used as prefix of filter predicates
+	Name string
+	// QuotedColumn is quoted column name
+	QuotedColumn string
+	// ColumnFormat is quoted column name, escaped for fmt.Sprintf() format
+	ColumnFormat string
+	// Type is not nullable Go type of predicates values
+	Type string
+	// HasIn is true if column has "<Name>In" predicate of values list
+	HasIn bool
+	// HasBetween is true if column has "<Name>Between" predicate of values range
+	HasBetween bool
+	// HasLike is true if column has "<Name>Like" predicate of text pattern
+	HasLike bool
+	// HasIsNull is true if column has "<Name>IsNull" predicate for nullable column
+	HasIsNull bool
+}
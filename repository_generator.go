@@ -0,0 +1,1552 @@
+package gorep
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed repository.template
+var templateRepositoryFile string
+
+//go:embed repository_in_memory.template
+var templateRepositoryInMemoryFile string
+
+const (
+	defaultSoftDeleteColumn = "deleted_at"
+	defaultVersionColumn    = "version"
+)
+
+// RepositoryGenerator generates repositories with SQL queries for tables, views and materialized views.
+// Repository is generated for DTO of the same table, so it must be placed in DTO package.
+type RepositoryGenerator struct {
+	database           Database
+	dtoGenerator       *DtoGenerator
+	templateRepository string
+	templateInMemory   string
+	templateFileSystem fs.FS
+	templatePatterns   []string
+	templateFunctions  template.FuncMap
+	cursorIndexes      map[string]string
+	softDeleteColumn   string
+	versionColumns     map[string]string
+	auditColumns       map[AuditColumnKind][]string
+	tenantColumn       string
+}
+
+func NewRepositoryGenerator(database Database, options ...RepositoryGeneratorOption) *RepositoryGenerator {
+	generator := &RepositoryGenerator{
+		database:           database,
+		dtoGenerator:       NewDtoGenerator(database),
+		templateRepository: templateRepositoryFile,
+		templateInMemory:   templateRepositoryInMemoryFile,
+		softDeleteColumn:   defaultSoftDeleteColumn,
+	}
+	for _, option := range options {
+		option(generator)
+	}
+
+	return generator
+}
+
+// Generate generates repository for table as file content string. Repositories of views and materialized views
+// are read-only and have only find methods.
+func (g *RepositoryGenerator) Generate(packageName string, tableName string) (string, error) {
+	return g.GenerateContext(context.Background(), packageName, tableName)
+}
+
+// GenerateContext generates repository for table as file content string. Database queries are cancelled with
+// context.
+func (g *RepositoryGenerator) GenerateContext(
+	ctx context.Context,
+	packageName string,
+	tableName string,
+) (string, error) {
+	if len(packageName) == 0 {
+		return "", errors.New("package name must not be empty")
+	}
+
+	if len(tableName) == 0 {
+		return "", errors.New("table name must not be empty")
+	}
+
+	templator, err := parseTemplate(
+		"repository.template",
+		g.templateRepository,
+		g.templateFileSystem,
+		g.templatePatterns,
+		mergeTemplateFunctions(g.templateFunctions),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := g.createTemplateData(ctx, packageName, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	data.Imports = g.createImports(data)
+
+	var buffer bytes.Buffer
+	err = templator.Execute(&buffer, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// GenerateInMemory generates in-memory repository for table as file content string. In-memory repository implements
+// the same interface as repository, storing rows in map by primary key, and could be used in unit tests instead of
+// database. It must be placed in the same package as repository. Table must have primary key.
+func (g *RepositoryGenerator) GenerateInMemory(packageName string, tableName string) (string, error) {
+	return g.GenerateInMemoryContext(context.Background(), packageName, tableName)
+}
+
+// GenerateInMemoryContext generates in-memory repository for table as file content string. Database queries are
+// cancelled with context.
+func (g *RepositoryGenerator) GenerateInMemoryContext(
+	ctx context.Context,
+	packageName string,
+	tableName string,
+) (string, error) {
+	if len(packageName) == 0 {
+		return "", errors.New("package name must not be empty")
+	}
+
+	if len(tableName) == 0 {
+		return "", errors.New("table name must not be empty")
+	}
+
+	templator, err := parseTemplate(
+		"repository_in_memory.template",
+		g.templateInMemory,
+		g.templateFileSystem,
+		g.templatePatterns,
+		mergeTemplateFunctions(g.templateFunctions),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := g.createTemplateData(ctx, packageName, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	if data.RelationKind.IsReadOnly() || len(data.PrimaryKeys) == 0 {
+		return "", errors.New("in-memory repository could be generated only for table with primary key")
+	}
+
+	data.InMemory = g.createInMemory(data)
+	data.Imports = g.createInMemoryImports(data)
+
+	var buffer bytes.Buffer
+	err = templator.Execute(&buffer, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// createTemplateData fetches columns, indexes and foreign keys of table and creates repository methods
+func (g *RepositoryGenerator) createTemplateData(
+	ctx context.Context,
+	packageName string,
+	tableName string,
+) (RepositoryTemplateData, error) {
+	fields, relationKind, err := g.dtoGenerator.fetchFields(ctx, tableName)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	if len(fields) == 0 {
+		return RepositoryTemplateData{}, errors.New("table was not found or has no columns")
+	}
+
+	sort.Slice(
+		fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		},
+	)
+
+	schema, tableName := g.dtoGenerator.parseSchemaAndTableName(tableName)
+	identifiers := []string{schema, tableName}
+	for _, field := range fields {
+		identifiers = append(identifiers, field.Name)
+	}
+
+	for _, identifier := range identifiers {
+		if strings.Contains(identifier, "`") {
+			return RepositoryTemplateData{}, fmt.Errorf("identifier \"%s\" must not contain backquote", identifier)
+		}
+	}
+
+	var primaryKeys []DatabaseField
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			primaryKeys = append(primaryKeys, field)
+		}
+	}
+
+	indexes, err := g.fetchIndexes(ctx, schema, tableName)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	foreignKeys, err := g.fetchForeignKeys(ctx, schema, tableName)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	converter := StringCaseConverter{}
+	data := RepositoryTemplateData{
+		PackageName:   packageName,
+		TableName:     tableName,
+		StructName:    converter.SnakeCaseToCamelCase(tableName) + "Repository",
+		DTOStructName: converter.SnakeCaseToCamelCase(tableName) + "DTO",
+		InterfaceName: converter.SnakeCaseToCamelCase(tableName) + "RepositoryInterface",
+		RelationKind:  relationKind,
+		Fields:        fields,
+		PrimaryKeys:   primaryKeys,
+		Indexes:       indexes,
+		ForeignKeys:   foreignKeys,
+	}
+
+	relation := g.quoteIdentifier(schema) + "." + g.quoteIdentifier(tableName)
+
+	data.Tenant, err = g.findTenant(fields)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	var tenantScope queryScope
+	if data.Tenant != nil {
+		tenantScope.tenantColumn = g.quoteIdentifier(data.Tenant.Field.Name)
+	}
+
+	scope := tenantScope
+	data.SoftDeleteField, scope.condition = g.findSoftDeleteField(fields)
+
+	data.VersionField, err = g.findVersionField(schema, tableName, fields)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	data.Finders, err = g.createFinders(tableName, relation, scope, fields, primaryKeys, indexes, foreignKeys)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	if data.SoftDeleteField != nil {
+		data.Finders, err = g.appendFinder(
+			data.Finders,
+			RepositoryMethod{
+				Name:              "FindWithDeleted",
+				Query:             g.createFindAllQuery(relation, tenantScope, fields, primaryKeys),
+				Arguments:         tenantScope.arguments(),
+				IsDeletedIncluded: true,
+			},
+		)
+		if err != nil {
+			return RepositoryTemplateData{}, err
+		}
+	}
+
+	data.Iteration = g.createIteration(tableName, data.Finders[0].Query)
+
+	data.Filter = g.createFilter(tableName, relation, scope, fields, primaryKeys, data.SoftDeleteField)
+
+	data.Pagination, err = g.createPagination(schema, tableName, relation, scope, fields, primaryKeys, indexes)
+	if err != nil {
+		return RepositoryTemplateData{}, err
+	}
+
+	switch {
+	case relationKind == RelationKindMaterializedView:
+		data.Refresh = &RepositoryMethod{Name: "Refresh", Query: "REFRESH MATERIALIZED VIEW " + relation}
+	case !relationKind.IsReadOnly():
+		var immutableColumns map[string]struct{}
+		data.Audit, immutableColumns, err = g.createAudit(fields)
+		if err != nil {
+			return RepositoryTemplateData{}, err
+		}
+
+		if immutableColumns == nil {
+			immutableColumns = make(map[string]struct{})
+		}
+
+		if data.Tenant != nil {
+			immutableColumns[data.Tenant.Field.Name] = struct{}{}
+		}
+
+		// deletion time is set only by Delete() and Restore() methods
+		if data.SoftDeleteField != nil {
+			immutableColumns[data.SoftDeleteField.Name] = struct{}{}
+		}
+
+		data.Insert = g.createInsert(relation, fields)
+		data.InsertMany, data.CopyFrom = g.createBulkInserts(schema, tableName, relation, fields)
+		if len(primaryKeys) > 0 {
+			data.Update = g.createUpdate(relation, scope, fields, data.VersionField, immutableColumns)
+			if data.Update != nil {
+				data.UpdateColumns = g.createUpdateColumns(
+					relation,
+					scope,
+					fields,
+					data.VersionField,
+					data.Audit,
+					immutableColumns,
+				)
+			}
+			data.Delete = g.createDelete(relation, tenantScope, primaryKeys)
+			if data.SoftDeleteField != nil {
+				data.Delete, data.Restore, data.HardDelete = g.createSoftDeletes(
+					relation,
+					tenantScope,
+					*data.SoftDeleteField,
+					primaryKeys,
+				)
+			}
+		}
+	}
+
+	data.IsClockUsed = data.Restore != nil || (data.Audit != nil && data.Audit.Insert.IsClockUsed)
+
+	return data, nil
+}
+
+// createFinders creates FindAll() method, ordered by primary key, find method by primary key, find methods by
+// indexes and relation loaders by foreign keys. Finders by unique indexes return single DTO. Tenant column is
+// skipped in finders by indexes and foreign keys.
+func (g *RepositoryGenerator) createFinders(
+	tableName string,
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	primaryKeys []DatabaseField,
+	indexes []DatabaseIndex,
+	foreignKeys []DatabaseForeignKey,
+) ([]RepositoryMethod, error) {
+	selectQuery := fmt.Sprintf("SELECT %s FROM %s", g.quoteColumns(fields), relation)
+
+	var order string
+	if len(primaryKeys) > 0 {
+		order = " ORDER BY " + g.quoteColumns(primaryKeys)
+	}
+
+	finders := []RepositoryMethod{
+		{
+			Name:      "FindAll",
+			Query:     g.createFindAllQuery(relation, scope, fields, primaryKeys),
+			Arguments: scope.arguments(),
+		},
+	}
+	if len(primaryKeys) > 0 {
+		findByPrimaryKey := g.createFindBy(selectQuery, scope, primaryKeys)
+		findByPrimaryKey.IsUnique = true
+		finders = append(finders, findByPrimaryKey)
+	}
+
+	fieldsMap := make(map[string]DatabaseField, len(fields))
+	for _, field := range fields {
+		fieldsMap[field.Name] = field
+	}
+
+	var err error
+	for _, index := range indexes {
+		indexFields := g.unscopedFields(scope, fieldsMap, index.Columns)
+		if len(indexFields) == 0 {
+			continue
+		}
+
+		findByIndex := g.createFindBy(selectQuery, scope, indexFields)
+		findByIndex.IsUnique = index.IsUnique
+		if !index.IsUnique {
+			findByIndex.Query += order
+		}
+		finders, err = g.appendFinder(finders, findByIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, foreignKey := range foreignKeys {
+		foreignKeyFields := g.unscopedFields(scope, fieldsMap, foreignKey.Columns)
+		if len(foreignKeyFields) == 0 {
+			continue
+		}
+
+		findByForeignKey := g.createFindBy(selectQuery, scope, foreignKeyFields)
+		findByForeignKey.Query += order
+		finders, err = g.appendFinder(finders, findByForeignKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(foreignKeyFields) == 1 {
+			findForReferenced, ok := g.createFindForReferenced(tableName, selectQuery, scope, foreignKeyFields[0])
+			if ok {
+				findForReferenced.Query += order
+				finders, err = g.appendFinder(finders, findForReferenced)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return finders, nil
+}
+
+// unscopedFields returns fields of columns, except tenant column, as its value is set by scope
+func (g *RepositoryGenerator) unscopedFields(
+	scope queryScope,
+	fieldsMap map[string]DatabaseField,
+	columns []string,
+) []DatabaseField {
+	var fields []DatabaseField
+	for _, column := range columns {
+		if g.quoteIdentifier(column) != scope.tenantColumn {
+			fields = append(fields, fieldsMap[column])
+		}
+	}
+
+	return fields
+}
+
+// findTenant returns tenant column, set with options. Relation without tenant column is not scoped by tenant.
+func (g *RepositoryGenerator) findTenant(fields []DatabaseField) (*RepositoryTenant, error) {
+	if g.tenantColumn == "" {
+		return nil, nil
+	}
+
+	for _, field := range fields {
+		if field.Name != g.tenantColumn {
+			continue
+		}
+
+		if field.IsNullable {
+			return nil, fmt.Errorf("tenant column \"%s\" must be not nullable", field.Name)
+		}
+
+		return &RepositoryTenant{Field: field, Name: g.dtoFieldName(field), Type: field.Type}, nil
+	}
+
+	return nil, nil
+}
+
+// findSoftDeleteField returns soft delete column and condition of not deleted rows. Soft delete column must have
+// nullable time type, otherwise it is not used for soft delete.
+func (g *RepositoryGenerator) findSoftDeleteField(fields []DatabaseField) (*DatabaseField, string) {
+	for _, field := range fields {
+		if field.Name != g.softDeleteColumn || field.Type != g.dtoGenerator.mapNullableTypeName("time.Time") {
+			continue
+		}
+
+		softDeleteField := field
+
+		return &softDeleteField, g.quoteIdentifier(field.Name) + " IS NULL"
+	}
+
+	return nil, ""
+}
+
+// createAudit creates setting of audit columns, matched by name patterns, and returns audit columns, which must not
+// be updated. Timestamp audit columns must have time type, and actor audit columns must have string or integer type,
+// the same for all actor columns. Columns of other types are not audit columns. Nil audit is returned if there are
+// no audit columns.
+func (g *RepositoryGenerator) createAudit(fields []DatabaseField) (*RepositoryAudit, map[string]struct{}, error) {
+	audit := &RepositoryAudit{Insert: &RepositoryAuditMethod{}}
+	update := &RepositoryAuditMethod{}
+	immutableColumns := make(map[string]struct{})
+	for _, field := range fields {
+		if field.IsPrimaryKey || field.IsGenerated {
+			continue
+		}
+
+		kind, ok, err := g.matchAuditColumn(field.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		valueType := g.parameterType(field)
+		value := "now"
+		if !kind.IsTimestamp() {
+			value = "actor"
+		}
+
+		switch {
+		case kind.IsTimestamp() && valueType != "time.Time":
+			continue
+		case !kind.IsTimestamp() && valueType != "string" && valueType != "int64":
+			continue
+		case !kind.IsTimestamp() && audit.ActorType != "" && audit.ActorType != valueType:
+			return nil, nil, fmt.Errorf(
+				"audit column \"%s\" must have the same type as other actor columns, %s",
+				field.Name,
+				audit.ActorType,
+			)
+		case !kind.IsTimestamp():
+			audit.ActorType = valueType
+		}
+
+		if strings.HasPrefix(field.Type, "sql.Null") {
+			value = fmt.Sprintf("%s{%s: %s, Valid: true}", field.Type, strings.TrimPrefix(field.Type, "sql.Null"), value)
+		}
+
+		assignment := fmt.Sprintf("dto.%s = %s", g.dtoFieldName(field), value)
+		methods := []*RepositoryAuditMethod{audit.Insert}
+		if kind.IsUpdated() {
+			methods = append(methods, update)
+		} else {
+			immutableColumns[field.Name] = struct{}{}
+		}
+
+		for _, method := range methods {
+			method.Assignments = append(method.Assignments, assignment)
+			method.IsClockUsed = method.IsClockUsed || kind.IsTimestamp()
+			method.IsActorUsed = method.IsActorUsed || !kind.IsTimestamp()
+		}
+
+		audit.Fields = append(audit.Fields, field)
+	}
+
+	if len(audit.Fields) == 0 {
+		return nil, nil, nil
+	}
+
+	if len(update.Assignments) > 0 {
+		audit.Update = update
+	}
+
+	return audit, immutableColumns, nil
+}
+
+// matchAuditColumn returns kind of audit column, which name patterns match column name
+func (g *RepositoryGenerator) matchAuditColumn(column string) (AuditColumnKind, bool, error) {
+	kinds := []AuditColumnKind{AuditColumnCreatedAt, AuditColumnUpdatedAt, AuditColumnCreatedBy, AuditColumnUpdatedBy}
+	for _, kind := range kinds {
+		for _, pattern := range g.auditColumns[kind] {
+			isMatched, err := path.Match(pattern, column)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid audit column pattern \"%s\": %w", pattern, err)
+			}
+
+			if isMatched {
+				return kind, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// findVersionField returns optimistic locking version column of table. Version column must have not nullable integer
+// type. Default version column is skipped if table has no such column, but column, set by option, must exist.
+func (g *RepositoryGenerator) findVersionField(
+	schema string,
+	tableName string,
+	fields []DatabaseField,
+) (*DatabaseField, error) {
+	columnName, isOption := g.tableOption(g.versionColumns, schema, tableName)
+	if !isOption {
+		columnName = defaultVersionColumn
+	}
+
+	if columnName == "" {
+		return nil, nil
+	}
+
+	for _, field := range fields {
+		if field.Name != columnName {
+			continue
+		}
+
+		if field.Type != "int64" || field.IsPrimaryKey {
+			if !isOption {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("version column \"%s\" must be not nullable integer column out of primary key", columnName)
+		}
+
+		versionField := field
+
+		return &versionField, nil
+	}
+
+	if isOption {
+		return nil, fmt.Errorf("version column \"%s\" was not found", columnName)
+	}
+
+	return nil, nil
+}
+
+// createFindAllQuery creates query of all rows in scope, ordered by primary key
+func (g *RepositoryGenerator) createFindAllQuery(
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	primaryKeys []DatabaseField,
+) string {
+	query := fmt.Sprintf("SELECT %s FROM %s%s", g.quoteColumns(fields), relation, g.where(scope.conditions(0)))
+	if len(primaryKeys) > 0 {
+		query += " ORDER BY " + g.quoteColumns(primaryKeys)
+	}
+
+	return query
+}
+
+// createIteration creates iteration over rows of FindAll() query, directly or with server-side cursor. Cursor names
+// are numbered on each call, so nested and concurrent iterations in one transaction use different cursors.
+func (g *RepositoryGenerator) createIteration(tableName string, findAllQuery string) *RepositoryIteration {
+	return &RepositoryIteration{
+		Query:        findAllQuery,
+		CursorName:   g.quoteIdentifier(strings.ReplaceAll(tableName, "%", "%%") + "_cursor_%d"),
+		DeclareQuery: "DECLARE %s NO SCROLL CURSOR FOR " + strings.ReplaceAll(findAllQuery, "%", "%%"),
+		FetchQuery:   "FETCH FORWARD %d FROM %s",
+		CloseQuery:   "CLOSE %s",
+	}
+}
+
+// createFilter creates filter of columns with predicates, supported by column types. Tenant and soft delete columns
+// are not filtered, as rows are already scoped by them.
+func (g *RepositoryGenerator) createFilter(
+	tableName string,
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	primaryKeys []DatabaseField,
+	softDeleteField *DatabaseField,
+) *RepositoryFilter {
+	namePrefix := StringCaseConverter{}.SnakeCaseToCamelCase(tableName)
+	filter := &RepositoryFilter{
+		StructName:      namePrefix + "Filter",
+		OrderStructName: namePrefix + "Order",
+		ColumnTypeName:  namePrefix + "Column",
+		SelectQuery:     fmt.Sprintf("SELECT %s FROM %s", g.quoteColumns(fields), relation),
+		ScopeCondition:  scope.conditions(0),
+	}
+	if len(primaryKeys) > 0 {
+		filter.DefaultOrder = " ORDER BY " + g.quoteColumns(primaryKeys)
+	}
+
+	for _, field := range fields {
+		if g.quoteIdentifier(field.Name) == scope.tenantColumn {
+			continue
+		}
+
+		if softDeleteField != nil && field.Name == softDeleteField.Name {
+			continue
+		}
+
+		filterField := RepositoryFilterField{
+			Field:        field,
+			Name:         g.dtoFieldName(field),
+			QuotedColumn: g.quoteIdentifier(field.Name),
+			ColumnFormat: strings.ReplaceAll(g.quoteIdentifier(field.Name), "%", "%%"),
+			Type:         g.parameterType(field),
+			HasIsNull:    field.IsNullable,
+		}
+
+		switch filterField.Type {
+		case "string":
+			filterField.HasIn = true
+			filterField.HasLike = true
+		case "float64", "int64", "uint64", "time.Time":
+			filterField.HasIn = true
+			filterField.HasBetween = true
+		case "bool":
+			filterField.HasIn = true
+		}
+
+		filter.Fields = append(filter.Fields, filterField)
+	}
+
+	return filter
+}
+
+// createPagination creates offset and keyset pagination queries, ordered by primary key or by cursor index columns,
+// set with options. Pagination is not created for relations without primary key and cursor index.
+func (g *RepositoryGenerator) createPagination(
+	schema string,
+	tableName string,
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	primaryKeys []DatabaseField,
+	indexes []DatabaseIndex,
+) (*RepositoryPagination, error) {
+	keys := primaryKeys
+	if indexName, ok := g.tableOption(g.cursorIndexes, schema, tableName); ok {
+		fieldsMap := make(map[string]DatabaseField, len(fields))
+		for _, field := range fields {
+			fieldsMap[field.Name] = field
+		}
+
+		keys = nil
+		for _, index := range indexes {
+			if index.Name != indexName {
+				continue
+			}
+
+			if !index.IsUnique {
+				return nil, fmt.Errorf("cursor index \"%s\" must be unique", indexName)
+			}
+
+			for _, column := range index.Columns {
+				if fieldsMap[column].IsNullable {
+					return nil, fmt.Errorf("cursor index \"%s\" column \"%s\" must be not nullable", indexName, column)
+				}
+
+				keys = append(keys, fieldsMap[column])
+			}
+		}
+
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("cursor index \"%s\" was not found", indexName)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	selectQuery := fmt.Sprintf("SELECT %s FROM %s", g.quoteColumns(fields), relation)
+	order := " ORDER BY " + g.quoteColumns(keys)
+	keyPlaceholders, _ := placeholders(DialectPostgres, len(keys))
+	limitPlaceholder, _ := placeholder(DialectPostgres, len(keys)+1)
+
+	return &RepositoryPagination{
+		Fields:         keys,
+		PageQuery:      selectQuery + g.where(scope.conditions(2)) + order + " LIMIT $1 OFFSET $2",
+		FirstPageQuery: selectQuery + g.where(scope.conditions(1)) + order + " LIMIT $1",
+		NextPageQuery: fmt.Sprintf(
+			"%s%s%s LIMIT %s",
+			selectQuery,
+			g.where(fmt.Sprintf("(%s) > (%s)", g.quoteColumns(keys), keyPlaceholders), scope.conditions(len(keys)+1)),
+			order,
+			limitPlaceholder,
+		),
+	}, nil
+}
+
+// tableOption returns option value, set for table name with or without schema
+func (*RepositoryGenerator) tableOption(options map[string]string, schema string, tableName string) (string, bool) {
+	value, ok := options[schema+"."+tableName]
+	if !ok {
+		value, ok = options[tableName]
+	}
+
+	return value, ok
+}
+
+// createFindForReferenced creates batched relation loader, finding rows for many referenced rows with one query,
+// "FindOrdersForUserIds(userIds []int64)". Loader is created only for column types, supported by pq.Array().
+func (g *RepositoryGenerator) createFindForReferenced(
+	tableName string,
+	selectQuery string,
+	scope queryScope,
+	field DatabaseField,
+) (RepositoryMethod, bool) {
+	elementType := g.parameterType(field)
+	switch elementType {
+	case "bool", "float64", "int64", "string":
+	default:
+		return RepositoryMethod{}, false
+	}
+
+	converter := StringCaseConverter{}
+	pluralName := Inflector{}.Plural(converter.ToCamelCase(field.Name))
+	parameterName := g.parameterName(pluralName)
+
+	return RepositoryMethod{
+		Name:       fmt.Sprintf("Find%sFor%s", converter.ToPascalCase(tableName), converter.ToPascalCase(pluralName)),
+		Query:      selectQuery + g.where(g.quoteIdentifier(field.Name)+" = ANY($1)", scope.conditions(1)),
+		Parameters: []RepositoryParameter{{Name: parameterName, Type: "[]" + elementType}},
+		Arguments:  append([]string{"pq.Array(" + parameterName + ")"}, scope.arguments()...),
+		Conditions: []RepositoryCondition{{Name: g.dtoFieldName(field), Parameter: parameterName, IsAny: true}},
+	}, true
+}
+
+// appendFinder adds finder, if there is no finder with the same name and columns, for example finder by foreign key
+// columns, which are also indexed. Finders with the same name and different columns are not allowed.
+func (*RepositoryGenerator) appendFinder(finders []RepositoryMethod, finder RepositoryMethod) ([]RepositoryMethod, error) {
+	for _, existingFinder := range finders {
+		if existingFinder.Name != finder.Name {
+			continue
+		}
+
+		if !reflect.DeepEqual(existingFinder.Conditions, finder.Conditions) {
+			return nil, fmt.Errorf("repository method \"%s\" is generated for different columns", finder.Name)
+		}
+
+		return finders, nil
+	}
+
+	return append(finders, finder), nil
+}
+
+// createFindBy creates find method, filtering by equality of all fields
+func (g *RepositoryGenerator) createFindBy(selectQuery string, scope queryScope, fields []DatabaseField) RepositoryMethod {
+	condition, parameters, arguments := g.createCondition(fields, 1)
+	conditions := make([]RepositoryCondition, 0, len(fields))
+	for i, field := range fields {
+		conditions = append(conditions, RepositoryCondition{Name: g.dtoFieldName(field), Parameter: parameters[i].Name})
+	}
+
+	return RepositoryMethod{
+		Name:       "FindBy" + g.joinFieldNames(fields),
+		Query:      selectQuery + g.where(condition, scope.conditions(len(arguments))),
+		Parameters: parameters,
+		Arguments:  append(arguments, scope.arguments()...),
+		Conditions: conditions,
+	}
+}
+
+// createInsert creates insert method. Values of generated columns are returned into DTO.
+func (g *RepositoryGenerator) createInsert(relation string, fields []DatabaseField) *RepositoryMethod {
+	var insertFields []DatabaseField
+	var generatedFields []DatabaseField
+	for _, field := range fields {
+		if field.IsGenerated {
+			generatedFields = append(generatedFields, field)
+		} else {
+			insertFields = append(insertFields, field)
+		}
+	}
+
+	insert := &RepositoryMethod{Name: "Insert"}
+	if len(insertFields) == 0 {
+		insert.Query = fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", relation)
+	} else {
+		valuesPlaceholders, _ := placeholders(DialectPostgres, len(insertFields))
+		insert.Query = fmt.Sprintf(
+			"INSERT INTO %s (%s) VALUES (%s)",
+			relation,
+			g.quoteColumns(insertFields),
+			valuesPlaceholders,
+		)
+	}
+
+	for _, field := range insertFields {
+		insert.Arguments = append(insert.Arguments, "dto."+g.dtoFieldName(field))
+	}
+
+	if len(generatedFields) > 0 {
+		insert.Query += " RETURNING " + g.quoteColumns(generatedFields)
+		for _, field := range generatedFields {
+			insert.Returning = append(insert.Returning, "dto."+g.dtoFieldName(field))
+		}
+	}
+
+	return insert
+}
+
+// createBulkInserts creates bulk insert methods with multi-row "VALUES" queries and with "COPY" protocol. Values of
+// generated columns are not inserted. Methods are not created if there are no inserted columns.
+func (g *RepositoryGenerator) createBulkInserts(
+	schema string,
+	tableName string,
+	relation string,
+	fields []DatabaseField,
+) (*RepositoryMethod, *RepositoryCopy) {
+	var insertFields []DatabaseField
+	for _, field := range fields {
+		if !field.IsGenerated {
+			insertFields = append(insertFields, field)
+		}
+	}
+
+	if len(insertFields) == 0 {
+		return nil, nil
+	}
+
+	insertMany := &RepositoryMethod{
+		Name:  "InsertMany",
+		Query: fmt.Sprintf("INSERT INTO %s (%s) VALUES ", relation, g.quoteColumns(insertFields)),
+	}
+	copyFrom := &RepositoryCopy{Schema: schema, Table: tableName}
+	for _, field := range insertFields {
+		argument := "dto." + g.dtoFieldName(field)
+		insertMany.Arguments = append(insertMany.Arguments, argument)
+		copyFrom.Columns = append(copyFrom.Columns, field.Name)
+		copyFrom.Arguments = append(copyFrom.Arguments, argument)
+	}
+
+	return insertMany, copyFrom
+}
+
+// createUpdate creates update method of all columns by primary key, except generated and immutable columns, such as
+// "created_at" audit column, tenant and soft delete columns. Version column is not set from DTO, but checked and
+// incremented, new version is returned into DTO. Update method is not created if there are no columns to update.
+func (g *RepositoryGenerator) createUpdate(
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	versionField *DatabaseField,
+	immutableColumns map[string]struct{},
+) *RepositoryMethod {
+	var assignments []string
+	var primaryKeys []DatabaseField
+	update := &RepositoryMethod{Name: "Update"}
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			primaryKeys = append(primaryKeys, field)
+			continue
+		}
+
+		if _, ok := immutableColumns[field.Name]; ok || field.IsGenerated {
+			continue
+		}
+
+		if versionField != nil && field.Name == versionField.Name {
+			continue
+		}
+
+		parameterPlaceholder, _ := placeholder(DialectPostgres, len(assignments)+1)
+		assignments = append(assignments, g.quoteIdentifier(field.Name)+" = "+parameterPlaceholder)
+		update.Arguments = append(update.Arguments, "dto."+g.dtoFieldName(field))
+	}
+
+	argumentsCount := len(assignments)
+	if versionField != nil {
+		version := g.quoteIdentifier(versionField.Name)
+		assignments = append(assignments, version+" = "+version+" + 1")
+	}
+
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	condition, _, _ := g.createCondition(primaryKeys, argumentsCount+1)
+	for _, field := range primaryKeys {
+		update.Arguments = append(update.Arguments, "dto."+g.dtoFieldName(field))
+	}
+
+	if versionField != nil {
+		versionCondition, _, _ := g.createCondition([]DatabaseField{*versionField}, argumentsCount+len(primaryKeys)+1)
+		condition += " AND " + versionCondition
+		update.Arguments = append(update.Arguments, "dto."+g.dtoFieldName(*versionField))
+	}
+
+	update.Query = fmt.Sprintf(
+		"UPDATE %s SET %s%s",
+		relation,
+		strings.Join(assignments, ", "),
+		g.where(condition, scope.conditions(len(update.Arguments))),
+	)
+	update.Arguments = append(update.Arguments, scope.arguments()...)
+	if versionField != nil {
+		update.Query += " RETURNING " + g.quoteIdentifier(versionField.Name)
+		update.Returning = []string{"dto." + g.dtoFieldName(*versionField)}
+	}
+
+	return update
+}
+
+// createUpdateColumns creates update method of chosen columns by primary key. Columns, updated by Update() method,
+// could be chosen, except audit columns, which are always updated. Version column is checked and incremented as in
+// Update() method.
+func (g *RepositoryGenerator) createUpdateColumns(
+	relation string,
+	scope queryScope,
+	fields []DatabaseField,
+	versionField *DatabaseField,
+	audit *RepositoryAudit,
+	immutableColumns map[string]struct{},
+) *RepositoryUpdateColumns {
+	auditColumns := make(map[string]struct{})
+	if audit != nil {
+		for _, field := range audit.Fields {
+			auditColumns[field.Name] = struct{}{}
+		}
+	}
+
+	var primaryKeys []DatabaseField
+	updateColumns := &RepositoryUpdateColumns{Name: "UpdateColumns", QueryPrefix: fmt.Sprintf("UPDATE %s SET ", relation)}
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			primaryKeys = append(primaryKeys, field)
+			continue
+		}
+
+		if _, ok := immutableColumns[field.Name]; ok || field.IsGenerated {
+			continue
+		}
+
+		if versionField != nil && field.Name == versionField.Name {
+			continue
+		}
+
+		column := RepositoryUpdateColumn{
+			Name:         field.Name,
+			FieldName:    g.dtoFieldName(field),
+			ColumnFormat: strings.ReplaceAll(g.quoteIdentifier(field.Name), "%", "%%"),
+		}
+		if _, ok := auditColumns[field.Name]; ok {
+			updateColumns.AlwaysUpdatedColumns = append(updateColumns.AlwaysUpdatedColumns, column)
+		} else {
+			updateColumns.Columns = append(updateColumns.Columns, column)
+		}
+	}
+
+	condition, _, _ := g.createCondition(primaryKeys, 1)
+	for _, field := range primaryKeys {
+		updateColumns.Arguments = append(updateColumns.Arguments, "dto."+g.dtoFieldName(field))
+	}
+
+	if versionField != nil {
+		versionCondition, _, _ := g.createCondition([]DatabaseField{*versionField}, len(primaryKeys)+1)
+		condition += " AND " + versionCondition
+		updateColumns.Arguments = append(updateColumns.Arguments, "dto."+g.dtoFieldName(*versionField))
+	}
+
+	updateColumns.QuerySuffix = g.where(condition, scope.conditions(len(updateColumns.Arguments)))
+	updateColumns.Arguments = append(updateColumns.Arguments, scope.arguments()...)
+	for _, column := range updateColumns.AlwaysUpdatedColumns {
+		parameterPlaceholder, _ := placeholder(DialectPostgres, len(updateColumns.Arguments)+1)
+		updateColumns.Assignments = append(
+			updateColumns.Assignments,
+			g.quoteIdentifier(column.Name)+" = "+parameterPlaceholder,
+		)
+		updateColumns.Arguments = append(updateColumns.Arguments, "dto."+column.FieldName)
+	}
+
+	if versionField != nil {
+		version := g.quoteIdentifier(versionField.Name)
+		updateColumns.Assignments = append(updateColumns.Assignments, version+" = "+version+" + 1")
+		updateColumns.QuerySuffix += " RETURNING " + version
+		updateColumns.Returning = []string{"dto." + g.dtoFieldName(*versionField)}
+	}
+
+	return updateColumns
+}
+
+// createSoftDeletes creates soft delete method, setting deletion time of not deleted row, restore method, clearing
+// deletion time of deleted row, and hard delete method, deleting row permanently
+func (g *RepositoryGenerator) createSoftDeletes(
+	relation string,
+	scope queryScope,
+	softDeleteField DatabaseField,
+	primaryKeys []DatabaseField,
+) (*RepositoryMethod, *RepositoryMethod, *RepositoryMethod) {
+	column := g.quoteIdentifier(softDeleteField.Name)
+
+	// deletion time is the first argument, taken from repository clock
+	condition, parameters, arguments := g.createCondition(primaryKeys, 2)
+	softDelete := &RepositoryMethod{
+		Name: "Delete",
+		Query: fmt.Sprintf(
+			"UPDATE %s SET %s = $1%s",
+			relation,
+			column,
+			g.where(condition, column+" IS NULL", scope.conditions(len(arguments)+1)),
+		),
+		Parameters: parameters,
+		Arguments:  append(append([]string{"r.clock()"}, arguments...), scope.arguments()...),
+	}
+
+	condition, parameters, arguments = g.createCondition(primaryKeys, 1)
+	scopeCondition := scope.conditions(len(arguments))
+	arguments = append(arguments, scope.arguments()...)
+	restore := &RepositoryMethod{
+		Name: "Restore",
+		Query: fmt.Sprintf(
+			"UPDATE %s SET %s = NULL%s",
+			relation,
+			column,
+			g.where(condition, column+" IS NOT NULL", scopeCondition),
+		),
+		Parameters: parameters,
+		Arguments:  arguments,
+	}
+	hardDelete := g.createDelete(relation, scope, primaryKeys)
+	hardDelete.Name = "HardDelete"
+
+	return softDelete, restore, hardDelete
+}
+
+// createDelete creates delete method by primary key
+func (g *RepositoryGenerator) createDelete(
+	relation string,
+	scope queryScope,
+	primaryKeys []DatabaseField,
+) *RepositoryMethod {
+	condition, parameters, arguments := g.createCondition(primaryKeys, 1)
+
+	return &RepositoryMethod{
+		Name:       "Delete",
+		Query:      fmt.Sprintf("DELETE FROM %s%s", relation, g.where(condition, scope.conditions(len(arguments)))),
+		Parameters: parameters,
+		Arguments:  append(arguments, scope.arguments()...),
+	}
+}
+
+// createCondition returns SQL condition of fields equality to parameters, starting from placeholder number,
+// with method parameters and query arguments for them
+func (g *RepositoryGenerator) createCondition(
+	fields []DatabaseField,
+	placeholderNumber int,
+) (string, []RepositoryParameter, []string) {
+	var conditions []string
+	var parameters []RepositoryParameter
+	var arguments []string
+	for i, field := range fields {
+		parameterPlaceholder, _ := placeholder(DialectPostgres, placeholderNumber+i)
+		conditions = append(conditions, g.quoteIdentifier(field.Name)+" = "+parameterPlaceholder)
+
+		parameter := RepositoryParameter{Name: g.parameterName(field.Name), Type: g.parameterType(field)}
+		parameters = append(parameters, parameter)
+		arguments = append(arguments, parameter.Name)
+	}
+
+	return strings.Join(conditions, " AND "), parameters, arguments
+}
+
+func (g *RepositoryGenerator) createImports(data RepositoryTemplateData) []string {
+	var parameterFields []DatabaseField
+	for _, method := range g.methods(data) {
+		for _, parameter := range method.Parameters {
+			parameterFields = append(parameterFields, DatabaseField{Type: parameter.Type})
+		}
+	}
+
+	imports := g.dtoGenerator.appendImports(g.dtoGenerator.createImports(parameterFields), "context", "fmt", "github.com/jmoiron/sqlx", "sync/atomic")
+	for _, method := range g.methods(data) {
+		for _, argument := range method.Arguments {
+			if strings.HasPrefix(argument, "pq.") {
+				imports = g.dtoGenerator.appendImports(imports, "github.com/lib/pq")
+			}
+		}
+	}
+	if data.Update != nil || data.Delete != nil {
+		imports = g.dtoGenerator.appendImports(imports, "database/sql")
+	}
+
+	if data.Update != nil && data.VersionField != nil {
+		imports = g.dtoGenerator.appendImports(imports, "errors", "github.com/vehsamrak/gorep")
+	}
+
+	if data.UpdateColumns != nil {
+		imports = g.dtoGenerator.appendImports(imports, "strings")
+	}
+
+	if data.IsClockUsed {
+		imports = g.dtoGenerator.appendImports(imports, "time")
+	}
+
+	if data.Audit != nil {
+		imports = g.dtoGenerator.appendImports(imports, g.dtoGenerator.createImports(data.Audit.Fields)...)
+		if data.Audit.ActorType != "" {
+			imports = g.dtoGenerator.appendImports(imports, "github.com/vehsamrak/gorep")
+		}
+	}
+
+	if data.Tenant != nil {
+		imports = g.dtoGenerator.appendImports(imports, "github.com/vehsamrak/gorep")
+		imports = g.dtoGenerator.appendImports(
+			imports,
+			g.dtoGenerator.createImports([]DatabaseField{{Type: data.Tenant.Type}})...,
+		)
+	}
+
+	if data.InsertMany != nil {
+		imports = g.dtoGenerator.appendImports(imports, "strconv", "strings")
+	}
+
+	if data.CopyFrom != nil {
+		imports = g.dtoGenerator.appendImports(imports, "github.com/lib/pq")
+	}
+
+	if data.Filter != nil {
+		imports = g.dtoGenerator.appendImports(imports, "fmt", "strings")
+		for _, field := range data.Filter.Fields {
+			if field.HasIn {
+				imports = g.dtoGenerator.appendImports(imports, "github.com/lib/pq")
+			}
+
+			if field.HasIn || field.HasBetween {
+				imports = g.dtoGenerator.appendImports(
+					imports,
+					g.dtoGenerator.createImports([]DatabaseField{{Type: field.Type}})...,
+				)
+			}
+		}
+	}
+
+	if data.Pagination != nil {
+		imports = g.dtoGenerator.appendImports(imports, "encoding/base64", "encoding/json")
+		imports = g.dtoGenerator.appendImports(imports, g.dtoGenerator.createImports(data.Pagination.Fields)...)
+	}
+
+	return imports
+}
+
+// createInMemory creates in-memory repository of table with primary key. Unique indexes are checked on write, except
+// primary key index, which is checked by map key.
+func (g *RepositoryGenerator) createInMemory(data RepositoryTemplateData) *RepositoryInMemory {
+	inMemory := &RepositoryInMemory{
+		StructName:           data.StructName + "InMemory",
+		PrimaryKeyConstraint: data.TableName + "_pkey",
+	}
+
+	primaryKeyColumns := make([]string, 0, len(data.PrimaryKeys))
+	for _, field := range data.PrimaryKeys {
+		keyField := RepositoryInMemoryField{
+			Name:      g.dtoFieldName(field),
+			Type:      g.parameterType(field),
+			Parameter: g.parameterName(field.Name),
+		}
+		if strings.HasPrefix(keyField.Type, "[]") {
+			keyField.Type = "string"
+			keyField.Conversion = "string"
+		}
+
+		inMemory.KeyFields = append(inMemory.KeyFields, keyField)
+		primaryKeyColumns = append(primaryKeyColumns, field.Name)
+	}
+
+	sort.Strings(primaryKeyColumns)
+	for _, index := range data.Indexes {
+		if !index.IsUnique {
+			continue
+		}
+
+		indexColumns := append([]string{}, index.Columns...)
+		sort.Strings(indexColumns)
+		if strings.Join(indexColumns, ",") == strings.Join(primaryKeyColumns, ",") {
+			inMemory.PrimaryKeyConstraint = index.Name
+			continue
+		}
+
+		uniqueIndex := RepositoryInMemoryIndex{Name: index.Name}
+		for _, column := range index.Columns {
+			uniqueIndex.FieldNames = append(uniqueIndex.FieldNames, g.dtoFieldName(DatabaseField{Name: column}))
+		}
+
+		inMemory.UniqueIndexes = append(inMemory.UniqueIndexes, uniqueIndex)
+	}
+
+	updatedFields := make(map[string]struct{})
+	if data.Update != nil {
+		for _, argument := range data.Update.Arguments {
+			updatedFields[strings.TrimPrefix(argument, "dto.")] = struct{}{}
+		}
+	}
+
+	for _, field := range data.Fields {
+		name := g.dtoFieldName(field)
+		if field.IsGenerated && field.Type == "int64" {
+			inMemory.SequenceFields = append(inMemory.SequenceFields, name)
+		}
+
+		if strings.HasPrefix(field.Type, "[]") {
+			inMemory.CopiedFields = append(inMemory.CopiedFields, name)
+		}
+
+		_, isUpdated := updatedFields[name]
+		if !isUpdated && !field.IsPrimaryKey && (data.VersionField == nil || field.Name != data.VersionField.Name) {
+			inMemory.PreservedFields = append(inMemory.PreservedFields, name)
+		}
+	}
+
+	return inMemory
+}
+
+// createInMemoryImports returns import paths of packages, used by in-memory repository
+func (g *RepositoryGenerator) createInMemoryImports(data RepositoryTemplateData) []string {
+	var parameterFields []DatabaseField
+	for _, method := range g.methods(data) {
+		for _, parameter := range method.Parameters {
+			parameterFields = append(parameterFields, DatabaseField{Type: parameter.Type})
+		}
+	}
+
+	imports := g.dtoGenerator.appendImports(
+		g.dtoGenerator.createImports(parameterFields),
+		"bytes",
+		"context",
+		"database/sql",
+		"database/sql/driver",
+		"fmt",
+		"github.com/lib/pq",
+		"reflect",
+		"sort",
+		"sync",
+		"time",
+	)
+
+	if data.Update != nil && data.VersionField != nil {
+		imports = g.dtoGenerator.appendImports(imports, "github.com/vehsamrak/gorep")
+	}
+
+	if data.Tenant != nil {
+		imports = g.dtoGenerator.appendImports(imports, "github.com/vehsamrak/gorep")
+		imports = g.dtoGenerator.appendImports(
+			imports,
+			g.dtoGenerator.createImports([]DatabaseField{{Type: data.Tenant.Type}})...,
+		)
+	}
+
+	if data.Audit != nil && data.Audit.ActorType != "" {
+		imports = g.dtoGenerator.appendImports(imports, "github.com/vehsamrak/gorep")
+	}
+
+	if data.Filter != nil {
+		for _, field := range data.Filter.Fields {
+			if field.HasLike {
+				imports = g.dtoGenerator.appendImports(imports, "regexp", "strings")
+			}
+
+			if field.HasIn || field.HasBetween {
+				imports = g.dtoGenerator.appendImports(
+					imports,
+					g.dtoGenerator.createImports([]DatabaseField{{Type: field.Type}})...,
+				)
+			}
+		}
+	}
+
+	if data.Pagination != nil {
+		imports = g.dtoGenerator.appendImports(imports, "encoding/base64", "encoding/json")
+		imports = g.dtoGenerator.appendImports(imports, g.dtoGenerator.createImports(data.Pagination.Fields)...)
+	}
+
+	return imports
+}
+
+// methods returns all generated repository methods
+func (*RepositoryGenerator) methods(data RepositoryTemplateData) []RepositoryMethod {
+	methods := append([]RepositoryMethod{}, data.Finders...)
+	for _, method := range []*RepositoryMethod{
+		data.Insert,
+		data.InsertMany,
+		data.Update,
+		data.Delete,
+		data.Restore,
+		data.HardDelete,
+		data.Refresh,
+	} {
+		if method != nil {
+			methods = append(methods, *method)
+		}
+	}
+
+	return methods
+}
+
+// where returns "WHERE" clause of conditions, joined by "AND". Empty conditions are skipped, empty string is returned
+// if there are no conditions.
+func (*RepositoryGenerator) where(conditions ...string) string {
+	var nonEmptyConditions []string
+	for _, condition := range conditions {
+		if condition != "" {
+			nonEmptyConditions = append(nonEmptyConditions, condition)
+		}
+	}
+
+	if len(nonEmptyConditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(nonEmptyConditions, " AND ")
+}
+
+// queryScope is scope of relation rows, applied to all queries: condition of not deleted rows and tenant condition
+type queryScope struct {
+	// condition is condition of rows without parameters, empty if rows are not scoped
+	condition string
+	// tenantColumn is quoted tenant column, empty if rows are not scoped by tenant
+	tenantColumn string
+}
+
+// conditions returns scope conditions, joined by "AND". Tenant is compared with parameter, following query
+// arguments, as tenant is always passed as the last argument.
+func (s queryScope) conditions(argumentsCount int) string {
+	if s.tenantColumn == "" {
+		return s.condition
+	}
+
+	tenantPlaceholder, _ := placeholder(DialectPostgres, argumentsCount+1)
+	tenantCondition := s.tenantColumn + " = " + tenantPlaceholder
+	if s.condition == "" {
+		return tenantCondition
+	}
+
+	return s.condition + " AND " + tenantCondition
+}
+
+// arguments returns query arguments of scope conditions
+func (s queryScope) arguments() []string {
+	if s.tenantColumn == "" {
+		return nil
+	}
+
+	return []string{"tenant"}
+}
+
+func (*RepositoryGenerator) quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func (g *RepositoryGenerator) quoteColumns(fields []DatabaseField) string {
+	quotedColumns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		quotedColumns = append(quotedColumns, g.quoteIdentifier(field.Name))
+	}
+
+	return strings.Join(quotedColumns, ", ")
+}
+
+// dtoFieldName returns DTO structure field name of column, same as in DTO template
+func (*RepositoryGenerator) dtoFieldName(field DatabaseField) string {
+	return goIdentifier(StringCaseConverter{}.SnakeCaseToCamelCase(field.Name))
+}
+
+// joinFieldNames returns field names for method name, "UserIdAndRoleId"
+func (g *RepositoryGenerator) joinFieldNames(fields []DatabaseField) string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, StringCaseConverter{}.ToPascalCase(field.Name))
+	}
+
+	return strings.Join(names, "And")
+}
+
+// parameterType returns Go type of method parameter for column. Parameters of nullable columns are not nullable,
+// as NULL values are never equal to parameters.
+func (g *RepositoryGenerator) parameterType(field DatabaseField) string {
+	if field.IsNullable {
+		return g.dtoGenerator.mapDatabaseType(field.DatabaseType)
+	}
+
+	return field.Type
+}
+
+// fetchIndexes returns indexes of table with key columns in index order. Expression and partial indexes are skipped,
+// as they could not be used by finders with column parameters.
+func (g *RepositoryGenerator) fetchIndexes(ctx context.Context, schema string, tableName string) ([]DatabaseIndex, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT index_class.relname, pg_index.indisunique, pg_attribute.attname
+		FROM pg_index
+		JOIN pg_class ON pg_class.oid = pg_index.indrelid
+		JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+		JOIN pg_class index_class ON index_class.oid = pg_index.indexrelid
+		CROSS JOIN LATERAL unnest(pg_index.indkey::int2[]) WITH ORDINALITY AS index_columns(attnum, position)
+		JOIN pg_attribute
+			ON pg_attribute.attrelid = pg_index.indrelid
+			AND pg_attribute.attnum = index_columns.attnum
+		WHERE pg_namespace.nspname = $1
+			AND pg_class.relname = $2
+			AND pg_index.indpred IS NULL
+			AND pg_index.indexprs IS NULL
+			AND index_columns.position <= pg_index.indnkeyatts
+		ORDER BY index_class.relname, index_columns.position`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []DatabaseIndex
+	for rows.Next() {
+		var indexName string
+		var isUnique bool
+		var column string
+		err = rows.Scan(&indexName, &isUnique, &column)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != indexName {
+			indexes = append(indexes, DatabaseIndex{Name: indexName, IsUnique: isUnique})
+		}
+
+		index := &indexes[len(indexes)-1]
+		index.Columns = append(index.Columns, column)
+	}
+
+	return indexes, rows.Err()
+}
+
+// fetchForeignKeys returns foreign key constraints of table with columns in constraint order
+func (g *RepositoryGenerator) fetchForeignKeys(ctx context.Context, schema string, tableName string) ([]DatabaseForeignKey, error) {
+	rows, err := g.database.QueryContext(
+		ctx,
+		`SELECT pg_constraint.conname, referenced_class.relname, pg_attribute.attname, referenced_attribute.attname
+		FROM pg_constraint
+		JOIN pg_class ON pg_class.oid = pg_constraint.conrelid
+		JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+		JOIN pg_class referenced_class ON referenced_class.oid = pg_constraint.confrelid
+		CROSS JOIN LATERAL unnest(pg_constraint.conkey, pg_constraint.confkey)
+			WITH ORDINALITY AS foreign_key_columns(attnum, referenced_attnum, position)
+		JOIN pg_attribute
+			ON pg_attribute.attrelid = pg_constraint.conrelid
+			AND pg_attribute.attnum = foreign_key_columns.attnum
+		JOIN pg_attribute referenced_attribute
+			ON referenced_attribute.attrelid = pg_constraint.confrelid
+			AND referenced_attribute.attnum = foreign_key_columns.referenced_attnum
+		WHERE pg_constraint.contype = 'f' AND pg_namespace.nspname = $1 AND pg_class.relname = $2
+		ORDER BY pg_constraint.conname, foreign_key_columns.position`,
+		schema,
+		tableName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []DatabaseForeignKey
+	for rows.Next() {
+		var constraintName string
+		var referencedTable string
+		var column string
+		var referencedColumn string
+		err = rows.Scan(&constraintName, &referencedTable, &column, &referencedColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(foreignKeys) == 0 || foreignKeys[len(foreignKeys)-1].Name != constraintName {
+			foreignKeys = append(
+				foreignKeys, DatabaseForeignKey{Name: constraintName, ReferencedTable: referencedTable},
+			)
+		}
+
+		foreignKey := &foreignKeys[len(foreignKeys)-1]
+		foreignKey.Columns = append(foreignKey.Columns, column)
+		foreignKey.ReferencedColumns = append(foreignKey.ReferencedColumns, referencedColumn)
+	}
+
+	return foreignKeys, rows.Err()
+}
+
+// parameterName returns method parameter name of column, which does not conflict with names, used in template
+func (*RepositoryGenerator) parameterName(column string) string {
+	name := goIdentifier(StringCaseConverter{}.ToCamelCase(column))
+	switch name {
+	case "ctx", "r", "dto", "dtos", "err", "result", "query", "rows", "tenant":
+		name += "Value"
+	}
+
+	return name
+}
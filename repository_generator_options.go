@@ -0,0 +1,102 @@
+package gorep
+
+import (
+	"io/fs"
+	"text/template"
+)
+
+type RepositoryGeneratorOption func(generator *RepositoryGenerator)
+
+// WithRepositoryTemplate replaces repository template with template contents
+func WithRepositoryTemplate(templateContents string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.templateRepository = templateContents
+	}
+}
+
+// WithRepositoryInMemoryTemplate replaces in-memory repository template with template contents
+func WithRepositoryInMemoryTemplate(templateContents string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.templateInMemory = templateContents
+	}
+}
+
+// WithRepositoryTemplateFS replaces repository templates with template files of file system, matched by patterns.
+// File system must contain "repository.template" file, and "repository_in_memory.template" file to generate
+// in-memory repositories, other matched files could be used as associated templates. If no patterns are set, only
+// template file of generated repository is parsed.
+func WithRepositoryTemplateFS(templateFileSystem fs.FS, templatePatterns ...string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.templateFileSystem = templateFileSystem
+		generator.templatePatterns = templatePatterns
+	}
+}
+
+// WithRepositoryTemplateFunctions adds custom functions to repository template, overriding default functions with
+// same names
+func WithRepositoryTemplateFunctions(templateFunctions template.FuncMap) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.templateFunctions = templateFunctions
+	}
+}
+
+// WithRepositoryCursorIndex sets unique index of table, which columns are used as keyset pagination cursor instead
+// of primary key columns. Index columns must be not nullable. Table name could be prefixed with schema name.
+func WithRepositoryCursorIndex(tableName string, indexName string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		if generator.cursorIndexes == nil {
+			generator.cursorIndexes = make(map[string]string)
+		}
+
+		generator.cursorIndexes[tableName] = indexName
+	}
+}
+
+// WithRepositorySoftDeleteColumn sets name of soft delete column, "deleted_at" by default. Soft delete column must
+// be nullable timestamp. Finders of table with soft delete column skip deleted rows, and Delete() method sets
+// deletion time instead of deleting row. Empty column name disables soft delete.
+func WithRepositorySoftDeleteColumn(columnName string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.softDeleteColumn = columnName
+	}
+}
+
+// WithRepositoryVersionColumn sets optimistic locking version column of table, "version" by default. Version column
+// must be not nullable integer. Update() method of table with version column updates row only if its version was
+// not changed, increments version and returns gorep.ErrConcurrentModification if row was changed. Table name could
+// be prefixed with schema name. Empty column name disables optimistic locking of table.
+func WithRepositoryVersionColumn(tableName string, columnName string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		if generator.versionColumns == nil {
+			generator.versionColumns = make(map[string]string)
+		}
+
+		generator.versionColumns[tableName] = columnName
+	}
+}
+
+// WithRepositoryAuditColumns enables audit column kind with name patterns, matched with path.Match(), for example
+// "*_created_at". Without patterns, audit column name is the same as its kind, such as "created_at". Audit columns
+// are not set by repositories, unless their kinds are enabled.
+func WithRepositoryAuditColumns(kind AuditColumnKind, patterns ...string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		if generator.auditColumns == nil {
+			generator.auditColumns = make(map[AuditColumnKind][]string)
+		}
+
+		if len(patterns) == 0 {
+			patterns = []string{string(kind)}
+		}
+
+		generator.auditColumns[kind] = patterns
+	}
+}
+
+// WithRepositoryTenantColumn sets tenant discriminator column, which must be not nullable. Queries of relations with
+// tenant column are scoped by tenant, set with repository WithTenant() method or with gorep.ContextWithTenant(),
+// inserted rows are filled with tenant, and methods return gorep.ErrTenantNotSet if tenant is not set.
+func WithRepositoryTenantColumn(columnName string) RepositoryGeneratorOption {
+	return func(generator *RepositoryGenerator) {
+		generator.tenantColumn = columnName
+	}
+}
@@ -0,0 +1,664 @@
+package gorep
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/golang/mock/gomock"
+
+	"github.com/vehsamrak/gorep/test_data"
+	"github.com/vehsamrak/gorep/test_tools"
+)
+
+func TestRepositoryGenerator_Generate_testDatabase(t *testing.T) {
+	const (
+		packageName                          = "package_name"
+		tableName                            = "test"
+		viewName                             = "test_view"
+		materializedViewName                 = "test_materialized_view"
+		testRepositoryGoldenPath             = "test_data/test_repository.golden"
+		testRepositoryOfViewPath             = "test_data/test_repository_of_view.golden"
+		testRepositoryOfMaterializedViewPath = "test_data/test_repository_of_materialized_view.golden"
+	)
+
+	_, err := testDatabase.Exec("DROP MATERIALIZED VIEW IF EXISTS " + materializedViewName)
+	if err != nil {
+		t.Fatalf("materialized view drop error: %v", err)
+	}
+	_, err = testDatabase.Exec("DROP VIEW IF EXISTS " + viewName)
+	if err != nil {
+		t.Fatalf("view drop error: %v", err)
+	}
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"name":       makeNotNullable(databaseFieldTypeVarchar),
+			"created_at": databaseFieldTypeTimestamp,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	_, err = testDatabase.Exec(
+		"CREATE VIEW " + viewName + " AS SELECT id, name FROM " + tableName + ";" +
+			"CREATE MATERIALIZED VIEW " + materializedViewName + " AS SELECT id, name FROM " + tableName,
+	)
+	if err != nil {
+		t.Fatalf("views creation error: %v", err)
+	}
+	defer testDatabase.Exec("DROP MATERIALIZED VIEW " + materializedViewName + "; DROP VIEW " + viewName)
+
+	tests := []struct {
+		name         string
+		tableName    string
+		expectedPath string
+	}{
+		{
+			name:         "table with serial primary key, must return repository with find and write methods",
+			tableName:    tableName,
+			expectedPath: testRepositoryGoldenPath,
+		},
+		{
+			name:         "view, must return read-only repository",
+			tableName:    viewName,
+			expectedPath: testRepositoryOfViewPath,
+		},
+		{
+			name:         "materialized view, must return read-only repository with refresh method",
+			tableName:    materializedViewName,
+			expectedPath: testRepositoryOfMaterializedViewPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				generator := NewRepositoryGenerator(testDatabase)
+
+				result, err := generator.Generate(packageName, tt.tableName)
+
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_ForeignKeys(t *testing.T) {
+	const (
+		packageName                             = "package_name"
+		tableName                               = "orders"
+		usersTableName                          = "users"
+		couponsTableName                        = "coupons"
+		testRepositoryWithForeignKeysGoldenPath = "test_data/test_repository_with_foreign_keys.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	dropTable(testDatabase, usersTableName)
+	dropTable(testDatabase, couponsTableName)
+	createTable(testDatabase, usersTableName, map[string]string{"id": databaseFieldTypeSerial + " PRIMARY KEY"})
+	defer dropTable(testDatabase, usersTableName)
+	createTable(testDatabase, couponsTableName, map[string]string{"id": databaseFieldTypeSerial + " PRIMARY KEY"})
+	defer dropTable(testDatabase, couponsTableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"user_id":    makeNotNullable(databaseFieldTypeInt4) + " REFERENCES " + usersTableName + " (id)",
+			"coupon_id":  databaseFieldTypeInt4 + " REFERENCES " + couponsTableName + " (id)",
+			"seller_id":  databaseFieldTypeInt4 + " REFERENCES " + usersTableName + " (id)",
+			"created_at": makeNotNullable(databaseFieldTypeTimestamp),
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	expected := test_tools.GetFileContents(testRepositoryWithForeignKeysGoldenPath)
+
+	generator := NewRepositoryGenerator(testDatabase)
+	result, err := generator.Generate(packageName, tableName)
+
+	if err != nil {
+		t.Errorf("Generate() returned error: %v", err)
+	}
+	if result != expected {
+		t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+	}
+}
+
+func TestRepositoryGenerator_Generate_FinderNameCollision(t *testing.T) {
+	const tableName = "shipments"
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":           databaseFieldTypeSerial + " PRIMARY KEY",
+			"owner":        databaseFieldTypeInt4,
+			"owner_and_id": databaseFieldTypeInt4,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec(
+		`CREATE INDEX shipments_owner_id_idx ON shipments (owner, id);
+		CREATE INDEX shipments_owner_and_id_idx ON shipments (owner_and_id)`,
+	)
+	if err != nil {
+		t.Fatalf("indexes creation error: %v", err)
+	}
+	expectedError := "repository method \"FindByOwnerAndId\" is generated for different columns"
+
+	result, err := NewRepositoryGenerator(testDatabase).Generate("package_name", tableName)
+
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("Generate() must return error \"%s\", returned \"%v\"", expectedError, err)
+	}
+	if result != "" {
+		t.Errorf("Generate() must return empty result, returned \"%s\"", result)
+	}
+}
+
+func TestRepositoryGenerator_Generate_SoftDelete(t *testing.T) {
+	const (
+		packageName                            = "package_name"
+		tableName                              = "posts"
+		testRepositoryWithSoftDeleteGoldenPath = "test_data/test_repository_with_soft_delete.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"title":      makeNotNullable(databaseFieldTypeVarchar) + " UNIQUE",
+			"deleted_at": databaseFieldTypeTimestamptz,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	expected := test_tools.GetFileContents(testRepositoryWithSoftDeleteGoldenPath)
+
+	generator := NewRepositoryGenerator(testDatabase)
+	result, err := generator.Generate(packageName, tableName)
+
+	if err != nil {
+		t.Errorf("Generate() returned error: %v", err)
+	}
+	if result != expected {
+		t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+	}
+}
+
+func TestRepositoryGenerator_Generate_Version(t *testing.T) {
+	const (
+		packageName                         = "package_name"
+		tableName                           = "accounts"
+		testRepositoryWithVersionGoldenPath = "test_data/test_repository_with_version.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":      databaseFieldTypeSerial + " PRIMARY KEY",
+			"balance": makeNotNullable(databaseFieldTypeBigint),
+			"version": makeNotNullable(databaseFieldTypeInt4),
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+
+	tests := []struct {
+		name          string
+		options       []RepositoryGeneratorOption
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "table with version column, must return repository with optimistic locking update",
+			expectedPath: testRepositoryWithVersionGoldenPath,
+		},
+		{
+			name:          "nonexistent version column, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryVersionColumn(tableName, "revision")},
+			expectedError: "version column \"revision\" was not found",
+		},
+		{
+			name:          "primary key version column, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryVersionColumn("public."+tableName, "id")},
+			expectedError: "version column \"id\" must be not nullable integer column out of primary key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewRepositoryGenerator(testDatabase, tt.options...)
+
+				result, err := generator.Generate(packageName, tableName)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_Audit(t *testing.T) {
+	const (
+		packageName                       = "package_name"
+		tableName                         = "articles"
+		testRepositoryWithAuditGoldenPath = "test_data/test_repository_with_audit.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"title":      makeNotNullable(databaseFieldTypeVarchar),
+			"created_at": makeNotNullable(databaseFieldTypeTimestamp),
+			"updated_at": databaseFieldTypeTimestamp,
+			"created_by": makeNotNullable(databaseFieldTypeVarchar),
+			"updated_by": databaseFieldTypeVarchar,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+
+	tests := []struct {
+		name          string
+		options       []RepositoryGeneratorOption
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name: "table with audit columns, must return repository setting audit columns on write",
+			options: []RepositoryGeneratorOption{
+				WithRepositoryAuditColumns(AuditColumnCreatedAt),
+				WithRepositoryAuditColumns(AuditColumnUpdatedAt),
+				WithRepositoryAuditColumns(AuditColumnCreatedBy),
+				WithRepositoryAuditColumns(AuditColumnUpdatedBy, "updated_by", "*_updated_by"),
+			},
+			expectedPath: testRepositoryWithAuditGoldenPath,
+		},
+		{
+			name:          "invalid audit column pattern, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryAuditColumns(AuditColumnCreatedBy, "[")},
+			expectedError: "invalid audit column pattern \"[\": syntax error in pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewRepositoryGenerator(testDatabase, tt.options...)
+
+				result, err := generator.Generate(packageName, tableName)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_Tenant(t *testing.T) {
+	const (
+		packageName                        = "package_name"
+		tableName                          = "projects"
+		tenantColumn                       = "tenant_id"
+		testRepositoryWithTenantGoldenPath = "test_data/test_repository_with_tenant.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"tenant_id":  makeNotNullable(databaseFieldTypeBigint),
+			"name":       makeNotNullable(databaseFieldTypeVarchar),
+			"deleted_at": databaseFieldTypeTimestamp,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec("CREATE UNIQUE INDEX projects_tenant_id_name_key ON projects (tenant_id, name)")
+	if err != nil {
+		t.Fatalf("index creation error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		options       []RepositoryGeneratorOption
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "table with tenant column, must return repository with queries scoped by tenant",
+			options:      []RepositoryGeneratorOption{WithRepositoryTenantColumn(tenantColumn)},
+			expectedPath: testRepositoryWithTenantGoldenPath,
+		},
+		{
+			name:          "nullable tenant column, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryTenantColumn("deleted_at")},
+			expectedError: "tenant column \"deleted_at\" must be not nullable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewRepositoryGenerator(testDatabase, tt.options...)
+
+				result, err := generator.Generate(packageName, tableName)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_ReservedParameterNames(t *testing.T) {
+	const (
+		packageName  = "package_name"
+		tableName    = "workspaces"
+		tenantColumn = "tenant_id"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":        databaseFieldTypeSerial + " PRIMARY KEY",
+			"tenant_id": makeNotNullable(databaseFieldTypeBigint),
+			"tenant":    makeNotNullable(databaseFieldTypeVarchar),
+			"rows":      makeNotNullable(databaseFieldTypeInteger),
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec(
+		"CREATE INDEX workspaces_tenant_idx ON workspaces (tenant); CREATE INDEX workspaces_rows_idx ON workspaces (rows)",
+	)
+	if err != nil {
+		t.Fatalf("indexes creation error: %v", err)
+	}
+
+	generator := NewRepositoryGenerator(testDatabase, WithRepositoryTenantColumn(tenantColumn))
+
+	tests := []struct {
+		name             string
+		generate         func(packageName string, tableName string) (string, error)
+		expectedContents []string
+	}{
+		{
+			name:     "columns with names of repository variables, must return repository with renamed parameters",
+			generate: generator.Generate,
+			expectedContents: []string{
+				"FindByTenant(ctx context.Context, tenantValue string) ([]WorkspacesDTO, error) {",
+				"WHERE \"tenant\" = $1 AND \"tenant_id\" = $2 ORDER BY \"id\"`, tenantValue, tenant)",
+				"FindByRows(ctx context.Context, rowsValue int64) ([]WorkspacesDTO, error) {",
+			},
+		},
+		{
+			name:     "columns with names of in-memory repository variables, must return repository with renamed parameters",
+			generate: generator.GenerateInMemory,
+			expectedContents: []string{
+				"if !r.isEqual(dto.Tenant, tenantValue) {",
+				"if !r.isEqual(dto.Rows, rowsValue) {",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result, err := tt.generate(packageName, tableName)
+
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				for _, expectedContent := range tt.expectedContents {
+					if !strings.Contains(result, expectedContent) {
+						t.Errorf("Generate() result must contain \"%s\":\n%v", expectedContent, result)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_IndexesAndPagination(t *testing.T) {
+	const (
+		packageName                             = "package_name"
+		tableName                               = "users"
+		testRepositoryWithIndexesGoldenPath     = "test_data/test_repository_with_indexes.golden"
+		testRepositoryWithCursorIndexGoldenPath = "test_data/test_repository_with_cursor_index.golden"
+	)
+
+	dropTable(testDatabase, tableName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"email":      makeNotNullable(databaseFieldTypeVarchar) + " UNIQUE",
+			"first_name": makeNotNullable(databaseFieldTypeVarchar),
+			"last_name":  databaseFieldTypeVarchar,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	_, err := testDatabase.Exec(
+		`CREATE INDEX users_name_idx ON users (last_name, first_name);
+		CREATE INDEX users_lower_email_idx ON users (lower(email));
+		CREATE INDEX users_partial_first_name_idx ON users (first_name) WHERE last_name IS NULL`,
+	)
+	if err != nil {
+		t.Fatalf("indexes creation error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		options       []RepositoryGeneratorOption
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "table with indexes, must return repository with finders by indexes",
+			expectedPath: testRepositoryWithIndexesGoldenPath,
+		},
+		{
+			name:         "cursor index, must return repository with pagination by cursor index",
+			options:      []RepositoryGeneratorOption{WithRepositoryCursorIndex(tableName, "users_email_key")},
+			expectedPath: testRepositoryWithCursorIndexGoldenPath,
+		},
+		{
+			name:          "non-unique cursor index, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryCursorIndex(tableName, "users_name_idx")},
+			expectedError: "cursor index \"users_name_idx\" must be unique",
+		},
+		{
+			name:          "nonexistent cursor index, must return error",
+			options:       []RepositoryGeneratorOption{WithRepositoryCursorIndex("public."+tableName, "nonexistent")},
+			expectedError: "cursor index \"nonexistent\" was not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				generator := NewRepositoryGenerator(testDatabase, tt.options...)
+
+				result, err := generator.Generate(packageName, tableName)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_GenerateInMemory(t *testing.T) {
+	const (
+		packageName                 = "in_memory"
+		tableName                   = "users"
+		tableWithoutPrimaryKeyName  = "users_log"
+		usersDtoPath                = "test_data/in_memory/users_dto.go"
+		usersRepositoryPath         = "test_data/in_memory/users_repository.go"
+		usersRepositoryInMemoryPath = "test_data/in_memory/users_repository_in_memory.go"
+	)
+
+	dropTable(testDatabase, tableName)
+	dropTable(testDatabase, tableWithoutPrimaryKeyName)
+	createTable(
+		testDatabase, tableName, map[string]string{
+			"id":         databaseFieldTypeSerial + " PRIMARY KEY",
+			"email":      makeNotNullable(databaseFieldTypeVarchar) + " UNIQUE",
+			"first_name": makeNotNullable(databaseFieldTypeVarchar),
+			"last_name":  databaseFieldTypeVarchar,
+		},
+	)
+	createTable(
+		testDatabase, tableWithoutPrimaryKeyName, map[string]string{
+			"message": databaseFieldTypeVarchar,
+		},
+	)
+	defer dropTable(testDatabase, tableName)
+	defer dropTable(testDatabase, tableWithoutPrimaryKeyName)
+	_, err := testDatabase.Exec(
+		`CREATE INDEX users_name_idx ON users (last_name, first_name);
+		CREATE INDEX users_lower_email_idx ON users (lower(email));
+		CREATE INDEX users_partial_first_name_idx ON users (first_name) WHERE last_name IS NULL`,
+	)
+	if err != nil {
+		t.Fatalf("indexes creation error: %v", err)
+	}
+
+	generateDto := NewDtoGenerator(testDatabase).Generate
+	generateRepository := NewRepositoryGenerator(testDatabase).Generate
+	generateInMemory := NewRepositoryGenerator(testDatabase).GenerateInMemory
+
+	// generated files of test package are compiled and in-memory repository behaviour is tested in it
+	tests := []struct {
+		name          string
+		generate      func(packageName string, tableName string) (string, error)
+		tableName     string
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "table with primary key, must return in-memory repository",
+			generate:     generateInMemory,
+			tableName:    tableName,
+			expectedPath: usersRepositoryInMemoryPath,
+		},
+		{
+			name:         "DTO of in-memory repository test package, must be up to date",
+			generate:     generateDto,
+			tableName:    tableName,
+			expectedPath: usersDtoPath,
+		},
+		{
+			name:         "repository of in-memory repository test package, must be up to date",
+			generate:     generateRepository,
+			tableName:    tableName,
+			expectedPath: usersRepositoryPath,
+		},
+		{
+			name:          "table without primary key, must return error",
+			generate:      generateInMemory,
+			tableName:     tableWithoutPrimaryKeyName,
+			expectedError: "in-memory repository could be generated only for table with primary key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result, err := tt.generate(packageName, tt.tableName)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("Generate() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Generate() returned error: %v", err)
+				}
+				expected := test_tools.GetFileContents(tt.expectedPath)
+				if result != expected {
+					t.Errorf("Generate() result is not as expected:\n%v", diff.LineDiff(result, expected))
+				}
+			},
+		)
+	}
+}
+
+func TestRepositoryGenerator_Generate_mockDatabase(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	t.Run(
+		"database query error, must return error", func(t *testing.T) {
+			mockDatabase := test_data.NewMockDatabase(mockController)
+			mockDatabase.EXPECT().QueryContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+			generator := NewRepositoryGenerator(mockDatabase)
+
+			result, err := generator.Generate("package_name", "test")
+
+			if err == nil {
+				t.Errorf("Generate() must return error")
+			}
+			if result != "" {
+				t.Errorf("Generate() must return empty result, returned \"%s\"", result)
+			}
+		},
+	)
+
+	t.Run(
+		"invalid template file, must return parse error", func(t *testing.T) {
+			const (
+				invalidTemplateContents = "{{}}"
+			)
+			mockDatabase := test_data.NewMockDatabase(mockController)
+			expectedErrorMessage := "template: repository.template:1: missing value for command"
+			generator := NewRepositoryGenerator(mockDatabase, WithRepositoryTemplate(invalidTemplateContents))
+
+			_, err := generator.Generate("package_name", "test")
+
+			if err == nil || err.Error() != expectedErrorMessage {
+				t.Errorf("Generate() must return error \"%s\", returned \"%v\"", expectedErrorMessage, err)
+			}
+		},
+	)
+}
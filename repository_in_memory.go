@@ -0,0 +1,40 @@
+package gorep
+
+// RepositoryInMemory is in-memory repository of table with primary key, passed to in-memory repository template.
+// Rows are stored in map by primary key.
+type RepositoryInMemory struct {
+	// StructName is in-memory repository structure name
+	StructName string
+	// KeyFields are primary key columns of map key
+	KeyFields []RepositoryInMemoryField
+	// PrimaryKeyConstraint is name of primary key constraint, returned in unique violation error
+	PrimaryKeyConstraint string
+	// UniqueIndexes are unique indexes, checked on write, except primary key index
+	UniqueIndexes []RepositoryInMemoryIndex
+	// SequenceFields are DTO field names of generated integer columns, filled from sequence on insert
+	SequenceFields []string
+	// PreservedFields are DTO field names of columns, not changed by update, such as generated columns
+	PreservedFields []string
+	// CopiedFields are DTO field names of slice columns, copied with DTO
+	CopiedFields []string
+}
+
+// RepositoryInMemoryField is column of in-memory repository map key
+type RepositoryInMemoryField struct {
+	// Name is DTO field name
+	Name string
+	// Type is Go type of map key field
+	Type string
+	// Parameter is Go parameter name of column in repository methods
+	Parameter string
+	// Conversion is Go type conversion of not comparable column value to map key field type, empty if not needed
+	Conversion string
+}
+
+// RepositoryInMemoryIndex is unique index, checked by in-memory repository
+type RepositoryInMemoryIndex struct {
+	// Name is index name
+	Name string
+	// FieldNames are DTO field names of index columns
+	FieldNames []string
+}
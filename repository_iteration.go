@@ -0,0 +1,16 @@
+package gorep
+
+// RepositoryIteration is row by row iteration over all rows, passed to repository template
+type RepositoryIteration struct {
+	// Query is query of all rows, ordered by primary key, used by Each() method
+	Query string
+	// CursorName is quoted name of server-side cursor, it is fmt.Sprintf() format with cursor number "%d" verb
+	CursorName string
+	// DeclareQuery declares server-side cursor of all rows, used by EachWithCursor() method, it is fmt.Sprintf()
+	// format with cursor name "%s" verb
+	DeclareQuery string
+	// FetchQuery fetches rows from cursor, it is fmt.Sprintf() format with fetch size "%d" and cursor name "%s" verbs
+	FetchQuery string
+	// CloseQuery closes cursor, it is fmt.Sprintf() format with cursor name "%s" verb
+	CloseQuery string
+}
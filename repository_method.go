@@ -0,0 +1,39 @@
+package gorep
+
+// RepositoryMethod is generated repository method with prepared SQL query, passed to repository template
+type RepositoryMethod struct {
+	// Name is Go method name
+	Name string
+	// Query is SQL query with PostgreSQL placeholders
+	Query string
+	// Parameters are Go method parameters
+	Parameters []RepositoryParameter
+	// Arguments are Go expressions, passed as query arguments in placeholders order
+	Arguments []string
+	// Returning are Go expressions of DTO fields, scanned from "RETURNING" clause of query
+	Returning []string
+	// IsUnique is true if finder method returns single DTO instead of DTO slice
+	IsUnique bool
+	// Conditions are conditions of finder method columns, used by in-memory repository
+	Conditions []RepositoryCondition
+	// IsDeletedIncluded is true if finder method of table with soft delete column returns deleted rows
+	IsDeletedIncluded bool
+}
+
+// RepositoryCondition is condition of column equality to finder method parameter
+type RepositoryCondition struct {
+	// Name is DTO field name of column
+	Name string
+	// Parameter is Go parameter name
+	Parameter string
+	// IsAny is true if column is equal to any element of parameter slice
+	IsAny bool
+}
+
+// RepositoryParameter is Go parameter of generated repository method
+type RepositoryParameter struct {
+	// Name is Go parameter name
+	Name string
+	// Type is Go parameter type
+	Type string
+}
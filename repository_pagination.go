@@ -0,0 +1,13 @@
+package gorep
+
+// RepositoryPagination is offset and keyset pagination of repository, passed to repository template
+type RepositoryPagination struct {
+	// Fields are key columns of stable rows order: primary key or unique index columns
+	Fields []DatabaseField
+	// PageQuery is offset pagination query with limit and offset placeholders
+	PageQuery string
+	// FirstPageQuery is keyset pagination query of the first page with limit placeholder
+	FirstPageQuery string
+	// NextPageQuery is keyset pagination query of page after key with key columns and limit placeholders
+	NextPageQuery string
+}
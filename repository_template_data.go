@@ -0,0 +1,71 @@
+package gorep
+
+// RepositoryTemplateData is data, passed to repository template
+type RepositoryTemplateData struct {
+	// PackageName is package name of generated repository
+	PackageName string
+	// TableName is database table name without schema
+	TableName string
+	// StructName is repository structure name
+	StructName string
+	// DTOStructName is name of DTO structure, generated for the same table
+	DTOStructName string
+	// InterfaceName is name of repository methods interface, implemented by repository and in-memory repository
+	InterfaceName string
+	// RelationKind is kind of relation: table, view or materialized view
+	RelationKind RelationKind
+	// Fields are table columns, sorted by name
+	Fields []DatabaseField
+	// PrimaryKeys are primary key columns, sorted by name
+	PrimaryKeys []DatabaseField
+	// Indexes are indexes of table by columns without expressions and predicates, sorted by name
+	Indexes []DatabaseIndex
+	// ForeignKeys are foreign key constraints of table, sorted by name
+	ForeignKeys []DatabaseForeignKey
+	// Imports are import paths of packages, used by repository
+	Imports []string
+	// Finders are methods, returning DTOs: FindAll(), find methods by primary key, indexes and foreign keys
+	Finders []RepositoryMethod
+	// Iteration is row by row iteration over all rows of Each() and EachWithCursor() methods
+	Iteration *RepositoryIteration
+	// Filter is filter of FindBy() method with optional predicates of columns
+	Filter *RepositoryFilter
+	// Pagination is offset and keyset pagination, nil if relation has no primary key or cursor index
+	Pagination *RepositoryPagination
+	// Insert is insert method, nil for read-only relations
+	Insert *RepositoryMethod
+	// InsertMany is bulk insert method with multi-row "VALUES" queries, nil for read-only relations and tables
+	// without inserted columns. Query is "INSERT" query prefix, ending with "VALUES".
+	InsertMany *RepositoryMethod
+	// CopyFrom is bulk insert method with "COPY" protocol, nil for read-only relations and tables without inserted
+	// columns
+	CopyFrom *RepositoryCopy
+	// Update is update method by primary key, nil for read-only relations and tables without primary key
+	Update *RepositoryMethod
+	// UpdateColumns is update method of chosen columns by primary key, nil if there is no update method
+	UpdateColumns *RepositoryUpdateColumns
+	// Delete is delete method by primary key, nil for read-only relations and tables without primary key. Delete
+	// method of table with soft delete column sets deletion time instead of deleting row.
+	Delete *RepositoryMethod
+	// SoftDeleteField is soft delete column, nil if table has no soft delete column. Rows with not NULL value of soft
+	// delete column are skipped by finders.
+	SoftDeleteField *DatabaseField
+	// VersionField is optimistic locking version column, nil if table has no version column. Update() method checks
+	// and increments version.
+	VersionField *DatabaseField
+	// Tenant is tenant discriminator column, nil if tenant column is not set with options or relation has no such
+	// column. All methods, except Refresh(), are scoped by tenant.
+	Tenant *RepositoryTenant
+	// Audit sets audit columns in write methods, nil for read-only relations and tables without audit columns
+	Audit *RepositoryAudit
+	// IsClockUsed is true if repository takes audit timestamps or deletion time from its clock
+	IsClockUsed bool
+	// Restore clears deletion time of soft deleted row, nil if table has no soft delete column or primary key
+	Restore *RepositoryMethod
+	// HardDelete deletes soft deleted row permanently, nil if table has no soft delete column or primary key
+	HardDelete *RepositoryMethod
+	// Refresh is materialized view refresh method, nil for other relations
+	Refresh *RepositoryMethod
+	// InMemory is in-memory repository, set only for in-memory repository template
+	InMemory *RepositoryInMemory
+}
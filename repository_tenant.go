@@ -0,0 +1,12 @@
+package gorep
+
+// RepositoryTenant is tenant discriminator column of repository, passed to repository template. All queries of
+// repository are scoped by tenant, passed as the last query argument, and inserted rows are filled with tenant.
+type RepositoryTenant struct {
+	// Field is tenant column
+	Field DatabaseField
+	// Name is DTO field name of tenant column
+	Name string
+	// Type is Go type of tenant
+	Type string
+}
@@ -0,0 +1,34 @@
+package gorep
+
+// RepositoryUpdateColumns is update method of chosen columns of row by primary key, passed to repository template.
+// Query is joined of query prefix, assignments and query suffix.
+type RepositoryUpdateColumns struct {
+	// Name is Go method name
+	Name string
+	// QueryPrefix is beginning of "UPDATE" query, ending with "SET"
+	QueryPrefix string
+	// QuerySuffix is "WHERE" clause of row by primary key, followed by "RETURNING" clause of version column
+	QuerySuffix string
+	// Arguments are Go expressions, passed as query arguments of query suffix and always updated columns. Arguments
+	// of chosen columns are passed after them.
+	Arguments []string
+	// Assignments are assignments of always updated columns and version increment
+	Assignments []string
+	// Returning are Go expressions of DTO fields, scanned from "RETURNING" clause of query
+	Returning []string
+	// Columns are columns, which could be chosen for update, sorted by name
+	Columns []RepositoryUpdateColumn
+	// AlwaysUpdatedColumns are columns, updated regardless of chosen columns, such as "updated_at" audit column.
+	// Choice of these columns is ignored.
+	AlwaysUpdatedColumns []RepositoryUpdateColumn
+}
+
+// RepositoryUpdateColumn is column of update method of chosen columns
+type RepositoryUpdateColumn struct {
+	// Name is column name
+	Name string
+	// FieldName is DTO field name of column
+	FieldName string
+	// ColumnFormat is quoted column name, escaped for fmt.Sprintf() format
+	ColumnFormat string
+}
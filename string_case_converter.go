@@ -1,6 +1,7 @@
 package gorep
 
 import (
+	"strings"
 	"unicode"
 )
 
@@ -37,3 +38,70 @@ func (StringCaseConverter) Lowercase(input string) (result string) {
 
 	return string(letters)
 }
+
+// ToSnakeCase converts string of any case to snake case, such as "user_id" for "UserID"
+func (c StringCaseConverter) ToSnakeCase(input string) string {
+	return strings.ToLower(strings.Join(c.splitWords(input), "_"))
+}
+
+// ToKebabCase converts string of any case to kebab case, such as "user-id" for "UserID"
+func (c StringCaseConverter) ToKebabCase(input string) string {
+	return strings.ToLower(strings.Join(c.splitWords(input), "-"))
+}
+
+// ToPascalCase converts string of any case to pascal case, such as "UserId" for "user_id"
+func (c StringCaseConverter) ToPascalCase(input string) string {
+	var result strings.Builder
+	for _, word := range c.splitWords(input) {
+		letters := []rune(strings.ToLower(word))
+		letters[0] = unicode.ToUpper(letters[0])
+		result.WriteString(string(letters))
+	}
+
+	return result.String()
+}
+
+// ToCamelCase converts string of any case to camel case, such as "userId" for "user_id"
+func (c StringCaseConverter) ToCamelCase(input string) string {
+	pascalCase := c.ToPascalCase(input)
+	if pascalCase == "" {
+		return ""
+	}
+
+	return c.Lowercase(pascalCase)
+}
+
+// splitWords splits string by separators and case changes, keeping abbreviations as single word
+func (StringCaseConverter) splitWords(input string) []string {
+	letters := []rune(input)
+
+	var words []string
+	var word []rune
+	for i, letter := range letters {
+		if letter == '_' || letter == '-' || unicode.IsSpace(letter) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+
+			continue
+		}
+
+		if unicode.IsUpper(letter) && len(word) > 0 {
+			previous := word[len(word)-1]
+			isNextLower := i+1 < len(letters) && unicode.IsLower(letters[i+1])
+			if !unicode.IsUpper(previous) || isNextLower {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+
+		word = append(word, letter)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
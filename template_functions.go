@@ -0,0 +1,190 @@
+package gorep
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// SQL dialects, supported by identifier quoting and placeholder template functions
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite"
+)
+
+// TemplateFunctions returns functions, available in DTO, model, repository and query templates:
+//
+//   - Uppercase: converts snake case to camel case with first letter in upper case, "user_id" to "UserId"
+//   - Lowercase: converts first letter to lower case, "UserId" to "userId"
+//   - SnakeCase, CamelCase, PascalCase, KebabCase: convert string of any case, "UserID" to "user_id", "userId",
+//     "UserId" and "user-id"
+//   - Plural, Singular: convert english noun, "category" to "categories" and back
+//   - GoIdentifier: makes valid Go identifier, "type" to "type_"
+//   - QuoteIdentifier: quotes SQL identifier for dialect, {{ "user" | QuoteIdentifier "postgres" }} returns "user"
+//     in double quotes
+//   - QuoteIdentifiers: quotes list of SQL identifiers for dialect
+//   - Columns: returns column names of fields, {{ .Fields | Columns | Join ", " }} returns "id, name"
+//   - Join: joins strings with separator
+//   - Placeholder: returns query parameter placeholder for dialect by parameter number, "$1" or "?"
+//   - Placeholders: returns comma separated placeholders for dialect by parameters count, "$1, $2" or "?, ?"
+//   - IsNullable: returns true if field is nullable
+//   - IsPrimaryKey: returns true if field is part of table primary key
+func TemplateFunctions() template.FuncMap {
+	converter := StringCaseConverter{}
+	inflector := Inflector{}
+
+	return template.FuncMap{
+		"Uppercase":        converter.SnakeCaseToCamelCase,
+		"Lowercase":        converter.Lowercase,
+		"SnakeCase":        converter.ToSnakeCase,
+		"CamelCase":        converter.ToCamelCase,
+		"PascalCase":       converter.ToPascalCase,
+		"KebabCase":        converter.ToKebabCase,
+		"Plural":           inflector.Plural,
+		"Singular":         inflector.Singular,
+		"GoIdentifier":     goIdentifier,
+		"QuoteIdentifier":  quoteIdentifier,
+		"QuoteIdentifiers": quoteIdentifiers,
+		"Columns":          columns,
+		"Join":             join,
+		"Placeholder":      placeholder,
+		"Placeholders":     placeholders,
+		"IsNullable":       isNullable,
+		"IsPrimaryKey":     isPrimaryKey,
+	}
+}
+
+// mergeTemplateFunctions returns default template functions, extended and overridden with custom functions
+func mergeTemplateFunctions(customFunctions template.FuncMap) template.FuncMap {
+	functions := TemplateFunctions()
+	for name, function := range customFunctions {
+		functions[name] = function
+	}
+
+	return functions
+}
+
+func goIdentifier(name string) string {
+	identifier := []rune(name)
+	for i, letter := range identifier {
+		if letter != '_' && !unicode.IsLetter(letter) && !unicode.IsDigit(letter) {
+			identifier[i] = '_'
+		}
+	}
+
+	if len(identifier) == 0 || unicode.IsDigit(identifier[0]) {
+		identifier = append([]rune{'_'}, identifier...)
+	}
+
+	if token.IsKeyword(string(identifier)) {
+		identifier = append(identifier, '_')
+	}
+
+	return string(identifier)
+}
+
+func quoteIdentifier(dialect string, identifier string) (string, error) {
+	var quote string
+	switch dialect {
+	case DialectPostgres, DialectSQLite:
+		quote = `"`
+	case DialectMySQL:
+		quote = "`"
+	default:
+		return "", fmt.Errorf("unknown SQL dialect \"%s\"", dialect)
+	}
+
+	identifierParts := strings.Split(identifier, ".")
+	for i, identifierPart := range identifierParts {
+		identifierParts[i] = quote + strings.ReplaceAll(identifierPart, quote, quote+quote) + quote
+	}
+
+	return strings.Join(identifierParts, "."), nil
+}
+
+func quoteIdentifiers(dialect string, identifiers []string) ([]string, error) {
+	quotedIdentifiers := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		quotedIdentifier, err := quoteIdentifier(dialect, identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		quotedIdentifiers = append(quotedIdentifiers, quotedIdentifier)
+	}
+
+	return quotedIdentifiers, nil
+}
+
+func columns(fields interface{}) ([]string, error) {
+	var columnNames []string
+	switch typedFields := fields.(type) {
+	case []DatabaseField:
+		for _, field := range typedFields {
+			columnNames = append(columnNames, field.Name)
+		}
+	case []ModelField:
+		for _, field := range typedFields {
+			if !field.IsEmbedded {
+				columnNames = append(columnNames, field.Column)
+			}
+		}
+	case []string:
+		columnNames = typedFields
+	default:
+		return nil, fmt.Errorf("columns could not be taken from %T", fields)
+	}
+
+	return columnNames, nil
+}
+
+func join(separator string, values []string) string {
+	return strings.Join(values, separator)
+}
+
+func placeholder(dialect string, number int) (string, error) {
+	switch dialect {
+	case DialectPostgres:
+		return fmt.Sprintf("$%d", number), nil
+	case DialectMySQL, DialectSQLite:
+		return "?", nil
+	}
+
+	return "", fmt.Errorf("unknown SQL dialect \"%s\"", dialect)
+}
+
+func placeholders(dialect string, count int) (string, error) {
+	parameterPlaceholders := make([]string, 0, count)
+	for number := 1; number <= count; number++ {
+		parameterPlaceholder, err := placeholder(dialect, number)
+		if err != nil {
+			return "", err
+		}
+
+		parameterPlaceholders = append(parameterPlaceholders, parameterPlaceholder)
+	}
+
+	return strings.Join(parameterPlaceholders, ", "), nil
+}
+
+func isNullable(field interface{}) bool {
+	switch typedField := field.(type) {
+	case DatabaseField:
+		return typedField.IsNullable
+	case ModelField:
+		return typedField.IsNullable
+	}
+
+	return false
+}
+
+func isPrimaryKey(field interface{}) bool {
+	if databaseField, ok := field.(DatabaseField); ok {
+		return databaseField.IsPrimaryKey
+	}
+
+	return false
+}
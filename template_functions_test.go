@@ -0,0 +1,93 @@
+package gorep
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFunctions(t *testing.T) {
+	fields := []DatabaseField{
+		{Name: "id", Type: "int64", IsPrimaryKey: true},
+		{Name: "user_name", Type: "sql.NullString", IsNullable: true},
+	}
+	tests := []struct {
+		name          string
+		template      string
+		data          interface{}
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "case conversion functions, must convert string case",
+			template: `{{ "UserID" | SnakeCase }} {{ "user_id" | CamelCase }} {{ "user-id" | PascalCase }} {{ "HTTPServer" | KebabCase }}`,
+			expected: "user_id userId UserId http-server",
+		},
+		{
+			name:     "legacy case conversion functions, must convert string case",
+			template: `{{ "user_id" | Uppercase }} {{ "UserId" | Lowercase }}`,
+			expected: "UserId userId",
+		},
+		{
+			name:     "inflection functions, must convert nouns",
+			template: `{{ "category" | Plural }} {{ "box" | Plural }} {{ "day" | Plural }} {{ "person" | Plural }} {{ "categories" | Singular }} {{ "boxes" | Singular }} {{ "users" | Singular }} {{ "people" | Singular }}`,
+			expected: "categories boxes days people category box user person",
+		},
+		{
+			name:     "go identifier function, must make valid identifier",
+			template: `{{ "type" | GoIdentifier }} {{ "1st" | GoIdentifier }} {{ "user name" | GoIdentifier }} {{ "id" | GoIdentifier }}`,
+			expected: "type_ _1st user_name id",
+		},
+		{
+			name:     "identifier quoting functions, must quote identifiers for dialect",
+			template: `{{ "public.user" | QuoteIdentifier "postgres" }} {{ "user" | QuoteIdentifier "mysql" }} {{ "a\"b" | QuoteIdentifier "sqlite" }}`,
+			expected: "\"public\".\"user\" `user` \"a\"\"b\"",
+		},
+		{
+			name:     "column functions, must join quoted columns",
+			template: `{{ . | Columns | QuoteIdentifiers "postgres" | Join ", " }}`,
+			data:     fields,
+			expected: `"id", "user_name"`,
+		},
+		{
+			name:     "placeholder functions, must return placeholders for dialect",
+			template: `{{ Placeholder "postgres" 3 }} {{ Placeholders "postgres" 2 }} {{ Placeholders "mysql" 2 }}`,
+			expected: "$3 $1, $2 ?, ?",
+		},
+		{
+			name:     "field predicate functions, must return field properties",
+			template: `{{ range . }}{{ .Name }}:{{ IsNullable . }}:{{ IsPrimaryKey . }} {{ end }}`,
+			data:     fields,
+			expected: "id:false:true user_name:true:false ",
+		},
+		{
+			name:          "unknown dialect, must return error",
+			template:      `{{ "user" | QuoteIdentifier "oracle" }}`,
+			expectedError: "unknown SQL dialect \"oracle\"",
+		},
+		{
+			name:          "columns of unknown type, must return error",
+			template:      `{{ 1 | Columns }}`,
+			expectedError: "columns could not be taken from int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				templator := template.Must(template.New("test").Funcs(TemplateFunctions()).Parse(tt.template))
+
+				var buffer bytes.Buffer
+				err := templator.Execute(&buffer, tt.data)
+
+				if tt.expectedError == "" {
+					assert.Nil(t, err, err)
+					assert.Equal(t, tt.expected, buffer.String())
+				} else {
+					assert.ErrorContains(t, err, tt.expectedError)
+				}
+			},
+		)
+	}
+}
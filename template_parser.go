@@ -0,0 +1,28 @@
+package gorep
+
+import (
+	"io/fs"
+	"text/template"
+)
+
+// parseTemplate parses template contents, or template files of file system if it is set.
+// File system must contain file with template name, other matched files are parsed as associated templates.
+func parseTemplate(
+	templateName string,
+	templateContents string,
+	templateFileSystem fs.FS,
+	templatePatterns []string,
+	functions template.FuncMap,
+) (*template.Template, error) {
+	templator := template.New(templateName).Funcs(functions)
+
+	if templateFileSystem == nil {
+		return templator.Parse(templateContents)
+	}
+
+	if len(templatePatterns) == 0 {
+		templatePatterns = []string{templateName}
+	}
+
+	return templator.ParseFS(templateFileSystem, templatePatterns...)
+}
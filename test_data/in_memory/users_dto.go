@@ -0,0 +1,14 @@
+// Code was generated by GoRep. Please do not modify it!
+
+package in_memory
+
+import (
+	"database/sql"
+)
+
+type UsersDTO struct {
+    Email string `db:"email"`
+    FirstName string `db:"first_name"`
+    Id int64 `db:"id"`
+    LastName sql.NullString `db:"last_name"`
+}
@@ -0,0 +1,108 @@
+// Code was generated by GoRep. Please do not modify it!
+
+package in_memory
+
+import (
+	"database/sql"
+)
+
+type Users struct {
+    email string
+    firstName string
+    id int64
+    lastName sql.NullString
+    changedColumns map[string]struct{}
+}
+
+func NewUsers(
+    email string,
+    firstName string,
+    id int64,
+    lastName sql.NullString,
+) *Users {
+    return &Users{
+        email: email,
+        firstName: firstName,
+        id: id,
+        lastName: lastName,
+    }
+}
+
+func (m *Users) Email() string {
+    return m.email
+}
+
+func (m *Users) FirstName() string {
+    return m.firstName
+}
+
+func (m *Users) Id() int64 {
+    return m.id
+}
+
+func (m *Users) LastName() sql.NullString {
+    return m.lastName
+}
+
+func (m *Users) SetEmail(email string) {
+    m.email = email
+    m.markChanged("email")
+}
+
+func (m *Users) SetFirstName(firstName string) {
+    m.firstName = firstName
+    m.markChanged("first_name")
+}
+
+func (m *Users) SetId(id int64) {
+    m.id = id
+    m.markChanged("id")
+}
+
+func (m *Users) SetLastName(lastName sql.NullString) {
+    m.lastName = lastName
+    m.markChanged("last_name")
+}
+
+func NewUsersFromDTO(dto UsersDTO) *Users {
+    model := &Users{}
+    model.email = dto.Email
+    model.firstName = dto.FirstName
+    model.id = dto.Id
+    model.lastName = dto.LastName
+
+    return model
+}
+
+func (m *Users) ToDTO() UsersDTO {
+    dto := UsersDTO{}
+    dto.Email = m.email
+    dto.FirstName = m.firstName
+    dto.Id = m.id
+    dto.LastName = m.lastName
+
+    return dto
+}
+
+func (m *Users) ChangedColumns() []string {
+    var columns []string
+    for _, column := range []string{"email", "first_name", "id", "last_name"} {
+        if _, ok := m.changedColumns[column]; ok {
+            columns = append(columns, column)
+        }
+    }
+
+    return columns
+}
+
+func (m *Users) ResetChanges() {
+    m.changedColumns = nil
+}
+
+func (m *Users) markChanged(column string) {
+    if m.changedColumns == nil {
+        m.changedColumns = make(map[string]struct{})
+    }
+
+    m.changedColumns[column] = struct{}{}
+}
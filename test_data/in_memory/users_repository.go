@@ -0,0 +1,523 @@
+// Code was generated by GoRep. Please do not modify it!
+
+package in_memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// UsersRepositoryInterface is interface of UsersRepository methods, which could be replaced in tests
+type UsersRepositoryInterface interface {
+    FindAll(ctx context.Context) ([]UsersDTO, error)
+    FindById(ctx context.Context, id int64) (UsersDTO, error)
+    FindByEmail(ctx context.Context, email string) (UsersDTO, error)
+    FindByLastNameAndFirstName(ctx context.Context, lastName string, firstName string) ([]UsersDTO, error)
+    Each(ctx context.Context, fn func(dto UsersDTO) error) error
+    EachWithCursor(ctx context.Context, fetchSize int, fn func(dto UsersDTO) error) error
+    FindBy(ctx context.Context, filter UsersFilter, order []UsersOrder, limit int) ([]UsersDTO, error)
+    FindPage(ctx context.Context, limit int, offset int) ([]UsersDTO, error)
+    FindPageAfter(ctx context.Context, cursor string, limit int) ([]UsersDTO, string, error)
+    Insert(ctx context.Context, dto *UsersDTO) error
+    InsertMany(ctx context.Context, dtos []UsersDTO) error
+    CopyFrom(ctx context.Context, dtos []UsersDTO) error
+    Update(ctx context.Context, dto *UsersDTO) error
+    UpdateColumns(ctx context.Context, dto *UsersDTO, columns []string) error
+    Delete(ctx context.Context, id int64) error
+}
+
+var _ UsersRepositoryInterface = (*UsersRepository)(nil)
+
+type UsersRepository struct {
+    database *sqlx.DB
+    executor sqlx.ExtContext
+}
+
+func NewUsersRepository(database *sqlx.DB) *UsersRepository {
+    return &UsersRepository{database: database, executor: database}
+}
+
+// WithTx returns repository copy, executing queries in transaction
+func (r *UsersRepository) WithTx(tx *sqlx.Tx) *UsersRepository {
+    repository := *r
+    repository.executor = tx
+
+    return &repository
+}
+
+// RunInTx runs function with repository, bound to new transaction. Transaction is rolled back if function returns
+// error or panics, and committed otherwise. Repository, already bound to transaction, runs function in it.
+func (r *UsersRepository) RunInTx(ctx context.Context, fn func(repository *UsersRepository) error) error {
+    if _, ok := r.executor.(*sqlx.Tx); ok {
+        return fn(r)
+    }
+
+    tx, err := r.database.BeginTxx(ctx, nil)
+    if err != nil {
+        return err
+    }
+
+    defer func() {
+        if recovered := recover(); recovered != nil {
+            _ = tx.Rollback()
+            panic(recovered)
+        }
+    }()
+
+    err = fn(r.WithTx(tx))
+    if err != nil {
+        rollbackErr := tx.Rollback()
+        if rollbackErr != nil {
+            return fmt.Errorf("%w, rollback error: %v", err, rollbackErr)
+        }
+
+        return err
+    }
+
+    return tx.Commit()
+}
+
+func (r *UsersRepository) FindAll(ctx context.Context) ([]UsersDTO, error) {
+    var result []UsersDTO
+    err := sqlx.SelectContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" ORDER BY "id"`)
+
+    return result, err
+}
+
+func (r *UsersRepository) FindById(ctx context.Context, id int64) (UsersDTO, error) {
+    var result UsersDTO
+    err := sqlx.GetContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" WHERE "id" = $1`, id)
+
+    return result, err
+}
+
+func (r *UsersRepository) FindByEmail(ctx context.Context, email string) (UsersDTO, error) {
+    var result UsersDTO
+    err := sqlx.GetContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" WHERE "email" = $1`, email)
+
+    return result, err
+}
+
+func (r *UsersRepository) FindByLastNameAndFirstName(ctx context.Context, lastName string, firstName string) ([]UsersDTO, error) {
+    var result []UsersDTO
+    err := sqlx.SelectContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" WHERE "last_name" = $1 AND "first_name" = $2 ORDER BY "id"`, lastName, firstName)
+
+    return result, err
+}
+
+// Each calls function for each row, ordered by primary key. Rows are scanned one by one, without loading all rows
+// into memory. Iteration stops on the first function error, which is returned. Function must not execute queries
+// in transaction of repository, as its connection is busy with reading rows.
+func (r *UsersRepository) Each(ctx context.Context, fn func(dto UsersDTO) error) error {
+    rows, err := r.executor.QueryxContext(ctx, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" ORDER BY "id"`)
+    if err != nil {
+        return err
+    }
+    defer rows.Close()
+
+    return r.scanEach(rows, fn)
+}
+
+// usersRepositoryCursorSequence numbers cursors of EachWithCursor() calls, as cursor names must be unique in transaction
+var usersRepositoryCursorSequence uint64
+
+// EachWithCursor calls function for each row, ordered by primary key, fetching rows by fetch size from server-side cursor
+// in transaction. Fetched rows are passed to function after reading, so function could execute queries in transaction
+// of repository, including nested iterations. Iteration stops on the first function error, which is returned.
+func (r *UsersRepository) EachWithCursor(ctx context.Context, fetchSize int, fn func(dto UsersDTO) error) error {
+    if fetchSize <= 0 {
+        return fmt.Errorf("fetch size must be positive, %d given", fetchSize)
+    }
+
+    return r.RunInTx(ctx, func(repository *UsersRepository) error {
+        cursorName := fmt.Sprintf(`"users_cursor_%d"`, atomic.AddUint64(&usersRepositoryCursorSequence, 1))
+        _, err := repository.executor.ExecContext(ctx, fmt.Sprintf(`DECLARE %s NO SCROLL CURSOR FOR SELECT "email", "first_name", "id", "last_name" FROM "public"."users" ORDER BY "id"`, cursorName))
+        if err != nil {
+            return err
+        }
+
+        for {
+            var dtos []UsersDTO
+            err = sqlx.SelectContext(ctx, repository.executor, &dtos, fmt.Sprintf(`FETCH FORWARD %d FROM %s`, fetchSize, cursorName))
+            if err != nil {
+                return err
+            }
+
+            for _, dto := range dtos {
+                err = fn(dto)
+                if err != nil {
+                    return err
+                }
+            }
+
+            if len(dtos) < fetchSize {
+                break
+            }
+        }
+
+        _, err = repository.executor.ExecContext(ctx, fmt.Sprintf(`CLOSE %s`, cursorName))
+
+        return err
+    })
+}
+
+// scanEach scans rows into DTOs and calls function for each of them
+func (r *UsersRepository) scanEach(rows *sqlx.Rows, fn func(dto UsersDTO) error) error {
+    for rows.Next() {
+        var dto UsersDTO
+        err := rows.StructScan(&dto)
+        if err != nil {
+            return err
+        }
+
+        err = fn(dto)
+        if err != nil {
+            return err
+        }
+    }
+
+    return rows.Err()
+}
+
+// UsersFilter is optional predicates of UsersRepository.FindBy(), joined by "AND". Nil predicates are skipped.
+type UsersFilter struct {
+    EmailIn []string
+    EmailLike *string
+    FirstNameIn []string
+    FirstNameLike *string
+    IdIn []int64
+    IdBetween *[2]int64
+    LastNameIn []string
+    LastNameLike *string
+    LastNameIsNull *bool
+}
+
+// UsersColumn is column of "users", used in UsersOrder
+type UsersColumn string
+
+const (
+    UsersColumnEmail UsersColumn = "email"
+    UsersColumnFirstName UsersColumn = "first_name"
+    UsersColumnId UsersColumn = "id"
+    UsersColumnLastName UsersColumn = "last_name"
+)
+
+// UsersOrder is order of UsersRepository.FindBy() rows by column
+type UsersOrder struct {
+    Column       UsersColumn
+    IsDescending bool
+}
+
+// FindBy returns rows, matching filter, ordered by columns or by primary key if order is empty.
+// Not positive limit returns all rows.
+func (r *UsersRepository) FindBy(ctx context.Context, filter UsersFilter, order []UsersOrder, limit int) ([]UsersDTO, error) {
+    var conditions []string
+    var arguments []interface{}
+    if filter.EmailIn != nil {
+        arguments = append(arguments, pq.Array(filter.EmailIn))
+        conditions = append(conditions, fmt.Sprintf(`"email" = ANY($%d)`, len(arguments)))
+    }
+    if filter.EmailLike != nil {
+        arguments = append(arguments, *filter.EmailLike)
+        conditions = append(conditions, fmt.Sprintf(`"email" LIKE $%d`, len(arguments)))
+    }
+    if filter.FirstNameIn != nil {
+        arguments = append(arguments, pq.Array(filter.FirstNameIn))
+        conditions = append(conditions, fmt.Sprintf(`"first_name" = ANY($%d)`, len(arguments)))
+    }
+    if filter.FirstNameLike != nil {
+        arguments = append(arguments, *filter.FirstNameLike)
+        conditions = append(conditions, fmt.Sprintf(`"first_name" LIKE $%d`, len(arguments)))
+    }
+    if filter.IdIn != nil {
+        arguments = append(arguments, pq.Array(filter.IdIn))
+        conditions = append(conditions, fmt.Sprintf(`"id" = ANY($%d)`, len(arguments)))
+    }
+    if filter.IdBetween != nil {
+        arguments = append(arguments, filter.IdBetween[0], filter.IdBetween[1])
+        conditions = append(conditions, fmt.Sprintf(`"id" BETWEEN $%d AND $%d`, len(arguments)-1, len(arguments)))
+    }
+    if filter.LastNameIn != nil {
+        arguments = append(arguments, pq.Array(filter.LastNameIn))
+        conditions = append(conditions, fmt.Sprintf(`"last_name" = ANY($%d)`, len(arguments)))
+    }
+    if filter.LastNameLike != nil {
+        arguments = append(arguments, *filter.LastNameLike)
+        conditions = append(conditions, fmt.Sprintf(`"last_name" LIKE $%d`, len(arguments)))
+    }
+    if filter.LastNameIsNull != nil {
+        if *filter.LastNameIsNull {
+            conditions = append(conditions, `"last_name" IS NULL`)
+        } else {
+            conditions = append(conditions, `"last_name" IS NOT NULL`)
+        }
+    }
+
+    query := `SELECT "email", "first_name", "id", "last_name" FROM "public"."users"`
+    if len(conditions) > 0 {
+        query += " WHERE " + strings.Join(conditions, " AND ")
+    }
+
+    orderColumns := make([]string, 0, len(order))
+    for _, orderItem := range order {
+        var column string
+        switch orderItem.Column {
+        case UsersColumnEmail:
+            column = `"email"`
+        case UsersColumnFirstName:
+            column = `"first_name"`
+        case UsersColumnId:
+            column = `"id"`
+        case UsersColumnLastName:
+            column = `"last_name"`
+        default:
+            return nil, fmt.Errorf("invalid order column %q", orderItem.Column)
+        }
+
+        if orderItem.IsDescending {
+            column += " DESC"
+        }
+
+        orderColumns = append(orderColumns, column)
+    }
+
+    if len(orderColumns) > 0 {
+        query += " ORDER BY " + strings.Join(orderColumns, ", ")
+    } else {
+        query += ` ORDER BY "id"`
+    }
+
+    if limit > 0 {
+        arguments = append(arguments, limit)
+        query += fmt.Sprintf(" LIMIT $%d", len(arguments))
+    }
+
+    var result []UsersDTO
+    err := sqlx.SelectContext(ctx, r.executor, &result, query, arguments...)
+
+    return result, err
+}
+
+// FindPage returns page of rows by limit and offset, ordered by "id"
+func (r *UsersRepository) FindPage(ctx context.Context, limit int, offset int) ([]UsersDTO, error) {
+    if limit <= 0 {
+        return nil, fmt.Errorf("limit must be positive, %d given", limit)
+    }
+    if offset < 0 {
+        return nil, fmt.Errorf("offset must not be negative, %d given", offset)
+    }
+
+    var result []UsersDTO
+    err := sqlx.SelectContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" ORDER BY "id" LIMIT $1 OFFSET $2`, limit, offset)
+
+    return result, err
+}
+
+// FindPageAfter returns page of rows after cursor, ordered by "id", and cursor of the next page.
+// Empty cursor returns the first page. Empty next page cursor is returned for the last page.
+func (r *UsersRepository) FindPageAfter(ctx context.Context, cursor string, limit int) ([]UsersDTO, string, error) {
+    if limit <= 0 {
+        return nil, "", fmt.Errorf("limit must be positive, %d given", limit)
+    }
+
+    var result []UsersDTO
+    if cursor == "" {
+        err := sqlx.SelectContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" ORDER BY "id" LIMIT $1`, limit)
+        if err != nil {
+            return nil, "", err
+        }
+    } else {
+        key, err := r.decodeCursor(cursor)
+        if err != nil {
+            return nil, "", err
+        }
+
+        err = sqlx.SelectContext(ctx, r.executor, &result, `SELECT "email", "first_name", "id", "last_name" FROM "public"."users" WHERE ("id") > ($1) ORDER BY "id" LIMIT $2`, key.Id, limit)
+        if err != nil {
+            return nil, "", err
+        }
+    }
+
+    if len(result) < limit {
+        return result, "", nil
+    }
+
+    nextCursor, err := r.encodeCursor(result[len(result)-1])
+    if err != nil {
+        return nil, "", err
+    }
+
+    return result, nextCursor, nil
+}
+
+// usersRepositoryCursor is key of the last row of page, encoded in cursor
+type usersRepositoryCursor struct {
+    Id int64 `json:"id"`
+}
+
+func (r *UsersRepository) encodeCursor(dto UsersDTO) (string, error) {
+    key, err := json.Marshal(usersRepositoryCursor{Id: dto.Id})
+    if err != nil {
+        return "", err
+    }
+
+    return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func (r *UsersRepository) decodeCursor(cursor string) (usersRepositoryCursor, error) {
+    var key usersRepositoryCursor
+    data, err := base64.RawURLEncoding.DecodeString(cursor)
+    if err != nil {
+        return key, fmt.Errorf("invalid cursor: %w", err)
+    }
+
+    err = json.Unmarshal(data, &key)
+    if err != nil {
+        return key, fmt.Errorf("invalid cursor: %w", err)
+    }
+
+    return key, nil
+}
+
+func (r *UsersRepository) Insert(ctx context.Context, dto *UsersDTO) error {
+    return r.executor.QueryRowxContext(ctx, `INSERT INTO "public"."users" ("email", "first_name", "last_name") VALUES ($1, $2, $3) RETURNING "id"`, dto.Email, dto.FirstName, dto.LastName).Scan(&dto.Id)
+}
+
+// InsertMany inserts rows with multi-row "VALUES" queries, each query has at most 65535 parameters. Values of generated
+// columns are not returned. Rows are inserted with several queries, so use RunInTx() to insert them atomically.
+func (r *UsersRepository) InsertMany(ctx context.Context, dtos []UsersDTO) error {
+    const columnsCount = 3
+    const chunkSize = 65535 / columnsCount
+    for start := 0; start < len(dtos); start += chunkSize {
+        end := start + chunkSize
+        if end > len(dtos) {
+            end = len(dtos)
+        }
+
+        values := make([]string, 0, end-start)
+        arguments := make([]interface{}, 0, (end-start)*columnsCount)
+        for _, dto := range dtos[start:end] {
+            placeholders := make([]string, columnsCount)
+            for i := range placeholders {
+                placeholders[i] = "$" + strconv.Itoa(len(arguments)+i+1)
+            }
+
+            values = append(values, "("+strings.Join(placeholders, ", ")+")")
+            arguments = append(arguments, dto.Email, dto.FirstName, dto.LastName)
+        }
+
+        _, err := r.executor.ExecContext(ctx, `INSERT INTO "public"."users" ("email", "first_name", "last_name") VALUES `+strings.Join(values, ", "), arguments...)
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// CopyFrom inserts rows with "COPY" protocol in transaction. Values of generated columns are not returned.
+func (r *UsersRepository) CopyFrom(ctx context.Context, dtos []UsersDTO) error {
+    return r.RunInTx(ctx, func(repository *UsersRepository) error {
+        statement, err := repository.executor.(*sqlx.Tx).PrepareContext(ctx, pq.CopyInSchema("public", "users", "email", "first_name", "last_name"))
+        if err != nil {
+            return err
+        }
+
+        for _, dto := range dtos {
+            _, err = statement.ExecContext(ctx, dto.Email, dto.FirstName, dto.LastName)
+            if err != nil {
+                _ = statement.Close()
+                return err
+            }
+        }
+
+        _, err = statement.ExecContext(ctx)
+        if err != nil {
+            _ = statement.Close()
+            return err
+        }
+
+        return statement.Close()
+    })
+}
+
+func (r *UsersRepository) Update(ctx context.Context, dto *UsersDTO) error {
+    result, err := r.executor.ExecContext(ctx, `UPDATE "public"."users" SET "email" = $1, "first_name" = $2, "last_name" = $3 WHERE "id" = $4`, dto.Email, dto.FirstName, dto.LastName, dto.Id)
+    if err != nil {
+        return err
+    }
+
+    return r.checkRowsAffected(result)
+}
+
+// UpdateColumns updates only chosen columns of row, such as columns of model ChangedColumns() method, returning error if
+// column could not be updated. Empty columns do not update row.
+func (r *UsersRepository) UpdateColumns(ctx context.Context, dto *UsersDTO, columns []string) error {
+    if len(columns) == 0 {
+        return nil
+    }
+
+    arguments := make([]interface{}, 1, 1+len(columns))
+    assignments := make([]string, 0, len(columns))
+    isAssigned := make(map[string]bool, len(columns))
+    for _, column := range columns {
+        if isAssigned[column] {
+            continue
+        }
+
+        isAssigned[column] = true
+        switch column {
+        case "email":
+            arguments = append(arguments, dto.Email)
+            assignments = append(assignments, fmt.Sprintf(`"email" = $%d`, len(arguments)))
+        case "first_name":
+            arguments = append(arguments, dto.FirstName)
+            assignments = append(assignments, fmt.Sprintf(`"first_name" = $%d`, len(arguments)))
+        case "last_name":
+            arguments = append(arguments, dto.LastName)
+            assignments = append(assignments, fmt.Sprintf(`"last_name" = $%d`, len(arguments)))
+        default:
+            return fmt.Errorf("column %q could not be updated", column)
+        }
+    }
+
+    arguments[0] = dto.Id
+    query := `UPDATE "public"."users" SET ` + strings.Join(assignments, ", ") + ` WHERE "id" = $1`
+    result, err := r.executor.ExecContext(ctx, query, arguments...)
+    if err != nil {
+        return err
+    }
+
+    return r.checkRowsAffected(result)
+}
+
+func (r *UsersRepository) Delete(ctx context.Context, id int64) error {
+    result, err := r.executor.ExecContext(ctx, `DELETE FROM "public"."users" WHERE "id" = $1`, id)
+    if err != nil {
+        return err
+    }
+
+    return r.checkRowsAffected(result)
+}
+
+// checkRowsAffected returns sql.ErrNoRows if no rows were affected by query
+func (r *UsersRepository) checkRowsAffected(result sql.Result) error {
+    rowsAffected, err := result.RowsAffected()
+    if err != nil {
+        return err
+    }
+
+    if rowsAffected == 0 {
+        return sql.ErrNoRows
+    }
+
+    return nil
+}
@@ -0,0 +1,705 @@
+// Code was generated by GoRep. Please do not modify it!
+
+package in_memory
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UsersRepositoryInMemory is in-memory implementation of UsersRepositoryInterface for unit tests. Rows are stored in map
+// by primary key and copied on read and write. Unique indexes are checked on write, returning *pq.Error with
+// "unique_violation" code, and methods return the same errors as UsersRepository.
+type UsersRepositoryInMemory struct {
+    mutex     *sync.RWMutex
+    rows      map[usersRepositoryInMemoryKey]UsersDTO
+    sequences map[string]int64
+}
+
+var _ UsersRepositoryInterface = (*UsersRepositoryInMemory)(nil)
+
+// usersRepositoryInMemoryKey is primary key of row in UsersRepositoryInMemory
+type usersRepositoryInMemoryKey struct {
+    Id int64
+}
+
+func NewUsersRepositoryInMemory() *UsersRepositoryInMemory {
+    return &UsersRepositoryInMemory{
+        mutex:     &sync.RWMutex{},
+        rows:      make(map[usersRepositoryInMemoryKey]UsersDTO),
+        sequences: make(map[string]int64),
+    }
+}
+
+func (r *UsersRepositoryInMemory) FindAll(ctx context.Context) ([]UsersDTO, error) {
+    var result []UsersDTO
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return result, err
+    }
+
+    for _, dto := range rows {
+        result = append(result, dto)
+    }
+
+    return result, nil
+}
+
+func (r *UsersRepositoryInMemory) FindById(ctx context.Context, id int64) (UsersDTO, error) {
+    var result UsersDTO
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return result, err
+    }
+
+    for _, dto := range rows {
+        if !r.isEqual(dto.Id, id) {
+            continue
+        }
+
+        return dto, nil
+    }
+
+    return result, sql.ErrNoRows
+}
+
+func (r *UsersRepositoryInMemory) FindByEmail(ctx context.Context, email string) (UsersDTO, error) {
+    var result UsersDTO
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return result, err
+    }
+
+    for _, dto := range rows {
+        if !r.isEqual(dto.Email, email) {
+            continue
+        }
+
+        return dto, nil
+    }
+
+    return result, sql.ErrNoRows
+}
+
+func (r *UsersRepositoryInMemory) FindByLastNameAndFirstName(ctx context.Context, lastName string, firstName string) ([]UsersDTO, error) {
+    var result []UsersDTO
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return result, err
+    }
+
+    for _, dto := range rows {
+        if !r.isEqual(dto.LastName, lastName) {
+            continue
+        }
+
+        if !r.isEqual(dto.FirstName, firstName) {
+            continue
+        }
+
+        result = append(result, dto)
+    }
+
+    return result, nil
+}
+
+// Each calls function for each row, ordered by primary key. Iteration stops on the first function error, which is
+// returned.
+func (r *UsersRepositoryInMemory) Each(ctx context.Context, fn func(dto UsersDTO) error) error {
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return err
+    }
+
+    for _, dto := range rows {
+        err = fn(dto)
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// EachWithCursor calls function for each row, ordered by primary key. Iteration stops on the first function error,
+// which is returned.
+func (r *UsersRepositoryInMemory) EachWithCursor(ctx context.Context, fetchSize int, fn func(dto UsersDTO) error) error {
+    if fetchSize <= 0 {
+        return fmt.Errorf("fetch size must be positive, %d given", fetchSize)
+    }
+
+    return r.Each(ctx, fn)
+}
+
+// FindBy returns rows, matching filter, ordered by columns or by primary key if order is empty. Not positive limit
+// returns all rows.
+func (r *UsersRepositoryInMemory) FindBy(ctx context.Context, filter UsersFilter, order []UsersOrder, limit int) ([]UsersDTO, error) {
+    for _, orderItem := range order {
+        switch orderItem.Column {
+        case UsersColumnEmail:
+        case UsersColumnFirstName:
+        case UsersColumnId:
+        case UsersColumnLastName:
+        default:
+            return nil, fmt.Errorf("invalid order column %q", orderItem.Column)
+        }
+    }
+
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return nil, err
+    }
+
+    var result []UsersDTO
+    for _, dto := range rows {
+        if r.isFilterMatched(dto, filter) {
+            result = append(result, dto)
+        }
+    }
+
+    sort.SliceStable(result, func(i, j int) bool {
+        for _, orderItem := range order {
+            comparison := r.compareOrder(r.columnValue(result[i], orderItem.Column), r.columnValue(result[j], orderItem.Column), orderItem.IsDescending)
+            if comparison != 0 {
+                return comparison < 0
+            }
+        }
+
+        return false
+    })
+
+    if limit > 0 && len(result) > limit {
+        result = result[:limit]
+    }
+
+    return result, nil
+}
+
+// isFilterMatched returns true if DTO matches all predicates of filter
+func (r *UsersRepositoryInMemory) isFilterMatched(dto UsersDTO, filter UsersFilter) bool {
+    if filter.EmailIn != nil && !r.isAnyEqual(dto.Email, filter.EmailIn) {
+        return false
+    }
+
+    if filter.EmailLike != nil && !r.isLike(dto.Email, *filter.EmailLike) {
+        return false
+    }
+
+    if filter.FirstNameIn != nil && !r.isAnyEqual(dto.FirstName, filter.FirstNameIn) {
+        return false
+    }
+
+    if filter.FirstNameLike != nil && !r.isLike(dto.FirstName, *filter.FirstNameLike) {
+        return false
+    }
+
+    if filter.IdIn != nil && !r.isAnyEqual(dto.Id, filter.IdIn) {
+        return false
+    }
+
+    if filter.IdBetween != nil {
+        lowerComparison, isLowerComparable := r.compare(dto.Id, filter.IdBetween[0])
+        upperComparison, isUpperComparable := r.compare(dto.Id, filter.IdBetween[1])
+        if !isLowerComparable || !isUpperComparable || lowerComparison < 0 || upperComparison > 0 {
+            return false
+        }
+    }
+
+    if filter.LastNameIn != nil && !r.isAnyEqual(dto.LastName, filter.LastNameIn) {
+        return false
+    }
+
+    if filter.LastNameLike != nil && !r.isLike(dto.LastName, *filter.LastNameLike) {
+        return false
+    }
+
+    if filter.LastNameIsNull != nil && *filter.LastNameIsNull != (r.value(dto.LastName) == nil) {
+        return false
+    }
+
+    return true
+}
+
+// columnValue returns DTO field value of column
+func (r *UsersRepositoryInMemory) columnValue(dto UsersDTO, column UsersColumn) interface{} {
+    switch column {
+    case UsersColumnEmail:
+        return dto.Email
+    case UsersColumnFirstName:
+        return dto.FirstName
+    case UsersColumnId:
+        return dto.Id
+    case UsersColumnLastName:
+        return dto.LastName
+    }
+
+    return nil
+}
+
+// isLike returns true if string value matches pattern of "LIKE" operator
+func (r *UsersRepositoryInMemory) isLike(value interface{}, pattern string) bool {
+    text, ok := r.value(value).(string)
+    if !ok {
+        return false
+    }
+
+    var expression strings.Builder
+    isEscaped := false
+    for _, character := range pattern {
+        switch {
+        case isEscaped:
+            expression.WriteString(regexp.QuoteMeta(string(character)))
+            isEscaped = false
+        case character == '\\':
+            isEscaped = true
+        case character == '%':
+            expression.WriteString(".*")
+        case character == '_':
+            expression.WriteString(".")
+        default:
+            expression.WriteString(regexp.QuoteMeta(string(character)))
+        }
+    }
+
+    return regexp.MustCompile(`(?s)^` + expression.String() + `$`).MatchString(text)
+}
+
+// FindPage returns page of rows by limit and offset, ordered by "id"
+func (r *UsersRepositoryInMemory) FindPage(ctx context.Context, limit int, offset int) ([]UsersDTO, error) {
+    if limit <= 0 {
+        return nil, fmt.Errorf("limit must be positive, %d given", limit)
+    }
+    if offset < 0 {
+        return nil, fmt.Errorf("offset must not be negative, %d given", offset)
+    }
+
+    rows, err := r.pageRows(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    if offset >= len(rows) {
+        return nil, nil
+    }
+
+    rows = rows[offset:]
+    if len(rows) > limit {
+        rows = rows[:limit]
+    }
+
+    return rows, nil
+}
+
+// FindPageAfter returns page of rows after cursor, ordered by "id", and cursor of the next page.
+// Empty cursor returns the first page. Empty next page cursor is returned for the last page.
+func (r *UsersRepositoryInMemory) FindPageAfter(ctx context.Context, cursor string, limit int) ([]UsersDTO, string, error) {
+    if limit <= 0 {
+        return nil, "", fmt.Errorf("limit must be positive, %d given", limit)
+    }
+
+    rows, err := r.pageRows(ctx)
+    if err != nil {
+        return nil, "", err
+    }
+
+    if cursor != "" {
+        key, err := r.decodeCursor(cursor)
+        if err != nil {
+            return nil, "", err
+        }
+
+        lastKey := UsersDTO{Id: key.Id}
+        position := sort.Search(len(rows), func(i int) bool {
+            return r.comparePageKeys(rows[i], lastKey) > 0
+        })
+        rows = rows[position:]
+    }
+
+    if len(rows) == 0 {
+        return nil, "", nil
+    }
+
+    if len(rows) < limit {
+        return rows, "", nil
+    }
+
+    rows = rows[:limit]
+    nextCursor, err := r.encodeCursor(rows[len(rows)-1])
+    if err != nil {
+        return nil, "", err
+    }
+
+    return rows, nextCursor, nil
+}
+
+// pageRows returns rows, ordered by pagination key columns
+func (r *UsersRepositoryInMemory) pageRows(ctx context.Context) ([]UsersDTO, error) {
+    rows, err := r.scopedRows(ctx, false)
+    if err != nil {
+        return nil, err
+    }
+
+    sort.SliceStable(rows, func(i, j int) bool {
+        return r.comparePageKeys(rows[i], rows[j]) < 0
+    })
+
+    return rows, nil
+}
+
+// comparePageKeys compares rows by pagination key columns
+func (r *UsersRepositoryInMemory) comparePageKeys(a UsersDTO, b UsersDTO) int {
+    if comparison := r.compareOrder(a.Id, b.Id, false); comparison != 0 {
+        return comparison
+    }
+
+    return 0
+}
+
+func (r *UsersRepositoryInMemory) encodeCursor(dto UsersDTO) (string, error) {
+    key, err := json.Marshal(usersRepositoryCursor{Id: dto.Id})
+    if err != nil {
+        return "", err
+    }
+
+    return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func (r *UsersRepositoryInMemory) decodeCursor(cursor string) (usersRepositoryCursor, error) {
+    var key usersRepositoryCursor
+    data, err := base64.RawURLEncoding.DecodeString(cursor)
+    if err != nil {
+        return key, fmt.Errorf("invalid cursor: %w", err)
+    }
+
+    err = json.Unmarshal(data, &key)
+    if err != nil {
+        return key, fmt.Errorf("invalid cursor: %w", err)
+    }
+
+    return key, nil
+}
+
+func (r *UsersRepositoryInMemory) Insert(ctx context.Context, dto *UsersDTO) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    return r.insert(dto)
+}
+
+// InsertMany inserts rows atomically. Values of generated columns are not returned.
+func (r *UsersRepositoryInMemory) InsertMany(ctx context.Context, dtos []UsersDTO) error {
+    return r.insertAll(ctx, dtos)
+}
+
+// CopyFrom inserts rows atomically. Values of generated columns are not returned.
+func (r *UsersRepositoryInMemory) CopyFrom(ctx context.Context, dtos []UsersDTO) error {
+    return r.insertAll(ctx, dtos)
+}
+
+// insertAll inserts rows, restoring previous rows if any row could not be inserted
+func (r *UsersRepositoryInMemory) insertAll(ctx context.Context, dtos []UsersDTO) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    previousRows := make(map[usersRepositoryInMemoryKey]UsersDTO, len(r.rows))
+    for key, dto := range r.rows {
+        previousRows[key] = dto
+    }
+
+    for _, dto := range dtos {
+        err := r.insert(&dto)
+        if err != nil {
+            for key := range r.rows {
+                delete(r.rows, key)
+            }
+
+            for key, dto := range previousRows {
+                r.rows[key] = dto
+            }
+
+            return err
+        }
+    }
+
+    return nil
+}
+
+// insert fills generated columns of DTO and stores its copy. It must be called with locked mutex.
+func (r *UsersRepositoryInMemory) insert(dto *UsersDTO) error {
+    r.sequences["Id"]++
+    dto.Id = r.sequences["Id"]
+
+    key := r.key(*dto)
+    if _, ok := r.rows[key]; ok {
+        return r.uniqueViolation("users_pkey")
+    }
+
+    err := r.checkUniqueIndexes(*dto)
+    if err != nil {
+        return err
+    }
+
+    r.rows[key] = r.copyDTO(*dto)
+
+    return nil
+}
+
+func (r *UsersRepositoryInMemory) Update(ctx context.Context, dto *UsersDTO) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    key := r.key(*dto)
+    existingDTO, ok := r.rows[key]
+    if !ok || !r.isInScope(existingDTO, false) {
+        return sql.ErrNoRows
+    }
+
+    updatedDTO := r.copyDTO(*dto)
+
+    err := r.checkUniqueIndexes(updatedDTO)
+    if err != nil {
+        return err
+    }
+
+    r.rows[key] = updatedDTO
+
+    return nil
+}
+
+// UpdateColumns updates only chosen columns of row, such as columns of model ChangedColumns() method, returning error if
+// column could not be updated. Empty columns do not update row.
+func (r *UsersRepositoryInMemory) UpdateColumns(ctx context.Context, dto *UsersDTO, columns []string) error {
+    if len(columns) == 0 {
+        return nil
+    }
+
+    for _, column := range columns {
+        switch column {
+        case "email", "first_name", "last_name":
+        default:
+            return fmt.Errorf("column %q could not be updated", column)
+        }
+    }
+
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    key := r.key(*dto)
+    existingDTO, ok := r.rows[key]
+    if !ok || !r.isInScope(existingDTO, false) {
+        return sql.ErrNoRows
+    }
+
+    changedDTO := r.copyDTO(*dto)
+    updatedDTO := existingDTO
+    for _, column := range columns {
+        switch column {
+        case "email":
+            updatedDTO.Email = changedDTO.Email
+        case "first_name":
+            updatedDTO.FirstName = changedDTO.FirstName
+        case "last_name":
+            updatedDTO.LastName = changedDTO.LastName
+        }
+    }
+
+    err := r.checkUniqueIndexes(updatedDTO)
+    if err != nil {
+        return err
+    }
+
+    r.rows[key] = updatedDTO
+
+    return nil
+}
+
+func (r *UsersRepositoryInMemory) Delete(ctx context.Context, id int64) error {
+    return r.deleteRow(ctx, usersRepositoryInMemoryKey{Id: id})
+}
+
+// deleteRow deletes row by key, returning sql.ErrNoRows if row was not found
+func (r *UsersRepositoryInMemory) deleteRow(ctx context.Context, key usersRepositoryInMemoryKey) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
+    dto, ok := r.rows[key]
+    if !ok || !r.isInScope(dto, true) {
+        return sql.ErrNoRows
+    }
+
+    delete(r.rows, key)
+
+    return nil
+}
+
+// scopedRows returns copies of rows in scope, ordered by primary key
+func (r *UsersRepositoryInMemory) scopedRows(ctx context.Context, isDeletedIncluded bool) ([]UsersDTO, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    rows := make([]UsersDTO, 0, len(r.rows))
+    for _, dto := range r.rows {
+        if r.isInScope(dto, isDeletedIncluded) {
+            rows = append(rows, r.copyDTO(dto))
+        }
+    }
+
+    sort.Slice(rows, func(i, j int) bool {
+        if comparison := r.compareOrder(rows[i].Id, rows[j].Id, false); comparison != 0 {
+            return comparison < 0
+        }
+
+        return false
+    })
+
+    return rows, nil
+}
+
+// isInScope returns true if row is not deleted
+func (r *UsersRepositoryInMemory) isInScope(dto UsersDTO, isDeletedIncluded bool) bool {
+    return true
+}
+
+// checkUniqueIndexes returns unique violation error if other row has the same values of unique index columns. It must
+// be called with locked mutex.
+func (r *UsersRepositoryInMemory) checkUniqueIndexes(dto UsersDTO) error {
+    key := r.key(dto)
+    for otherKey, otherDTO := range r.rows {
+        if otherKey == key {
+            continue
+        }
+
+        if r.isEqual(dto.Email, otherDTO.Email) {
+            return r.uniqueViolation("users_email_key")
+        }
+
+    }
+
+    return nil
+}
+
+// uniqueViolation returns error of database driver with "unique_violation" code
+func (r *UsersRepositoryInMemory) uniqueViolation(constraint string) error {
+    return &pq.Error{
+        Severity:   "ERROR",
+        Code:       "23505",
+        Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+        Table:      "users",
+        Constraint: constraint,
+    }
+}
+
+// key returns primary key of row
+func (r *UsersRepositoryInMemory) key(dto UsersDTO) usersRepositoryInMemoryKey {
+    return usersRepositoryInMemoryKey{Id: dto.Id}
+}
+
+// copyDTO returns copy of DTO, which does not share slices with original DTO
+func (r *UsersRepositoryInMemory) copyDTO(dto UsersDTO) UsersDTO {
+    return dto
+}
+
+// value returns database value of DTO field or parameter, nil for NULL and not supported values
+func (r *UsersRepositoryInMemory) value(value interface{}) interface{} {
+    databaseValue, err := driver.DefaultParameterConverter.ConvertValue(value)
+    if err != nil {
+        return nil
+    }
+
+    return databaseValue
+}
+
+// compare compares values like database. Values are not comparable if any of them is NULL.
+func (r *UsersRepositoryInMemory) compare(a interface{}, b interface{}) (int, bool) {
+    a, b = r.value(a), r.value(b)
+    sign := func(isLess bool, isGreater bool) int {
+        switch {
+        case isLess:
+            return -1
+        case isGreater:
+            return 1
+        }
+
+        return 0
+    }
+
+    switch a := a.(type) {
+    case int64:
+        if b, ok := b.(int64); ok {
+            return sign(a < b, a > b), true
+        }
+    case float64:
+        if b, ok := b.(float64); ok {
+            return sign(a < b, a > b), true
+        }
+    case string:
+        if b, ok := b.(string); ok {
+            return sign(a < b, a > b), true
+        }
+    case bool:
+        if b, ok := b.(bool); ok {
+            return sign(!a && b, a && !b), true
+        }
+    case time.Time:
+        if b, ok := b.(time.Time); ok {
+            return sign(a.Before(b), a.After(b)), true
+        }
+    case []byte:
+        if b, ok := b.([]byte); ok {
+            return bytes.Compare(a, b), true
+        }
+    }
+
+    return 0, false
+}
+
+// compareOrder compares values for sorting. NULL values are greater than other values, like in database.
+func (r *UsersRepositoryInMemory) compareOrder(a interface{}, b interface{}, isDescending bool) int {
+    comparison, ok := r.compare(a, b)
+    if !ok {
+        isANull, isBNull := r.value(a) == nil, r.value(b) == nil
+        switch {
+        case isANull && !isBNull:
+            comparison = 1
+        case !isANull && isBNull:
+            comparison = -1
+        }
+    }
+
+    if isDescending {
+        return -comparison
+    }
+
+    return comparison
+}
+
+// isEqual returns true if values are equal. NULL values are not equal to any value.
+func (r *UsersRepositoryInMemory) isEqual(a interface{}, b interface{}) bool {
+    comparison, ok := r.compare(a, b)
+
+    return ok && comparison == 0
+}
+
+// isAnyEqual returns true if value is equal to any element of values slice
+func (r *UsersRepositoryInMemory) isAnyEqual(value interface{}, values interface{}) bool {
+    valuesSlice := reflect.ValueOf(values)
+    for i := 0; i < valuesSlice.Len(); i++ {
+        if r.isEqual(value, valuesSlice.Index(i).Interface()) {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,568 @@
+package in_memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func createUsersRepositoryInMemory(t *testing.T, emails ...string) *UsersRepositoryInMemory {
+	repository := NewUsersRepositoryInMemory()
+	for _, email := range emails {
+		err := repository.Insert(context.Background(), &UsersDTO{Email: email, FirstName: email})
+		if err != nil {
+			t.Fatalf("Insert() returned error: %v", err)
+		}
+	}
+
+	return repository
+}
+
+func userIds(users []UsersDTO) []int64 {
+	var ids []int64
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+
+	return ids
+}
+
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+func TestUsersRepositoryInMemory_Insert(t *testing.T) {
+	repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+	err := repository.Insert(context.Background(), &UsersDTO{Email: "first@example.com"})
+
+	if !isUniqueViolation(err, "users_email_key") {
+		t.Errorf("Insert() must return unique violation of \"users_email_key\", returned \"%v\"", err)
+	}
+}
+
+func TestUsersRepositoryInMemory_InsertMany(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"valid rows, must insert all rows with generated primary keys", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+			err := repository.InsertMany(
+				ctx,
+				[]UsersDTO{{Email: "second@example.com"}, {Email: "third@example.com"}},
+			)
+			if err != nil {
+				t.Fatalf("InsertMany() returned error: %v", err)
+			}
+
+			result, err := repository.FindAll(ctx)
+			if err != nil {
+				t.Fatalf("FindAll() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(userIds(result), []int64{1, 2, 3}) {
+				t.Errorf("InsertMany() inserted rows %v, expected %v", userIds(result), []int64{1, 2, 3})
+			}
+		},
+	)
+
+	t.Run(
+		"row violates unique index, must return error and insert no rows", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+			err := repository.InsertMany(
+				ctx,
+				[]UsersDTO{{Email: "second@example.com"}, {Email: "first@example.com"}},
+			)
+
+			if !isUniqueViolation(err, "users_email_key") {
+				t.Errorf("InsertMany() must return unique violation of \"users_email_key\", returned \"%v\"", err)
+			}
+			result, err := repository.FindAll(ctx)
+			if err != nil {
+				t.Fatalf("FindAll() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(userIds(result), []int64{1}) {
+				t.Errorf("InsertMany() must roll back inserted rows, found rows %v", userIds(result))
+			}
+		},
+	)
+}
+
+func TestUsersRepositoryInMemory_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"existing row, must update row", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+			dto := UsersDTO{Id: 1, Email: "changed@example.com", FirstName: "Changed"}
+
+			err := repository.Update(ctx, &dto)
+			if err != nil {
+				t.Fatalf("Update() returned error: %v", err)
+			}
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result != dto {
+				t.Errorf("Update() updated row to %v, expected %v", result, dto)
+			}
+		},
+	)
+
+	t.Run(
+		"email of other row, must return unique violation and keep row", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com", "second@example.com")
+
+			err := repository.Update(ctx, &UsersDTO{Id: 2, Email: "first@example.com"})
+
+			if !isUniqueViolation(err, "users_email_key") {
+				t.Errorf("Update() must return unique violation of \"users_email_key\", returned \"%v\"", err)
+			}
+			result, err := repository.FindById(ctx, 2)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result.Email != "second@example.com" {
+				t.Errorf("Update() must not change row, email is \"%s\"", result.Email)
+			}
+		},
+	)
+}
+
+func TestUsersRepositoryInMemory_MissingRow(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		method func(repository *UsersRepositoryInMemory) error
+	}{
+		{
+			name: "FindById() of missing row, must return sql.ErrNoRows",
+			method: func(repository *UsersRepositoryInMemory) error {
+				_, err := repository.FindById(ctx, 2)
+				return err
+			},
+		},
+		{
+			name: "Update() of missing row, must return sql.ErrNoRows",
+			method: func(repository *UsersRepositoryInMemory) error {
+				return repository.Update(ctx, &UsersDTO{Id: 2, Email: "second@example.com"})
+			},
+		},
+		{
+			name: "Delete() of missing row, must return sql.ErrNoRows",
+			method: func(repository *UsersRepositoryInMemory) error {
+				return repository.Delete(ctx, 2)
+			},
+		},
+		{
+			name: "Delete() of deleted row, must return sql.ErrNoRows",
+			method: func(repository *UsersRepositoryInMemory) error {
+				err := repository.Delete(ctx, 1)
+				if err != nil {
+					return err
+				}
+
+				return repository.Delete(ctx, 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+				err := tt.method(repository)
+
+				if !errors.Is(err, sql.ErrNoRows) {
+					t.Errorf("method must return sql.ErrNoRows, returned \"%v\"", err)
+				}
+			},
+		)
+	}
+}
+
+func TestUsersRepositoryInMemory_CopiesRows(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"inserted DTO changed after Insert(), must not change stored row", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t)
+			dto := UsersDTO{Email: "first@example.com"}
+			err := repository.Insert(ctx, &dto)
+			if err != nil {
+				t.Fatalf("Insert() returned error: %v", err)
+			}
+
+			dto.Email = "changed@example.com"
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result.Email != "first@example.com" {
+				t.Errorf("stored row must not be changed, email is \"%s\"", result.Email)
+			}
+		},
+	)
+
+	t.Run(
+		"updated DTO changed after Update(), must not change stored row", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+			dto := UsersDTO{Id: 1, Email: "updated@example.com"}
+			err := repository.Update(ctx, &dto)
+			if err != nil {
+				t.Fatalf("Update() returned error: %v", err)
+			}
+
+			dto.Email = "changed@example.com"
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result.Email != "updated@example.com" {
+				t.Errorf("stored row must not be changed, email is \"%s\"", result.Email)
+			}
+		},
+	)
+
+	t.Run(
+		"returned DTOs changed, must not change stored rows", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+			found, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			all, err := repository.FindAll(ctx)
+			if err != nil {
+				t.Fatalf("FindAll() returned error: %v", err)
+			}
+
+			found.Email = "changed@example.com"
+			all[0].Email = "changed@example.com"
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result.Email != "first@example.com" {
+				t.Errorf("stored row must not be changed, email is \"%s\"", result.Email)
+			}
+		},
+	)
+}
+
+func TestUsersRepositoryInMemory_FindBy(t *testing.T) {
+	ctx := context.Background()
+	repository := createUsersRepositoryInMemory(t)
+	for _, dto := range []UsersDTO{
+		{Email: "first@example.com", FirstName: "Ann", LastName: sql.NullString{String: "Smith", Valid: true}},
+		{Email: "second@example.com", FirstName: "Bob"},
+		{Email: "third@example.org", FirstName: "Ann", LastName: sql.NullString{String: "Brown", Valid: true}},
+	} {
+		dto := dto
+		if err := repository.Insert(ctx, &dto); err != nil {
+			t.Fatalf("Insert() returned error: %v", err)
+		}
+	}
+
+	isNull := true
+	isNotNull := false
+	emailLike := "%@example.com"
+	firstNameLike := "_o_"
+	escapedLike := "An\\_"
+
+	tests := []struct {
+		name          string
+		filter        UsersFilter
+		order         []UsersOrder
+		limit         int
+		expectedIds   []int64
+		expectedError string
+	}{
+		{
+			name:        "empty filter, must return all rows ordered by primary key",
+			expectedIds: []int64{1, 2, 3},
+		},
+		{
+			name:        "filter by LIKE with percent wildcard, must return matching rows",
+			filter:      UsersFilter{EmailLike: &emailLike},
+			expectedIds: []int64{1, 2},
+		},
+		{
+			name:        "filter by LIKE with underscore wildcard, must return matching rows",
+			filter:      UsersFilter{FirstNameLike: &firstNameLike},
+			expectedIds: []int64{2},
+		},
+		{
+			name:        "filter by LIKE with escaped underscore, must match underscore literally",
+			filter:      UsersFilter{FirstNameLike: &escapedLike},
+			expectedIds: nil,
+		},
+		{
+			name:        "filter by values, must return rows with any of values",
+			filter:      UsersFilter{FirstNameIn: []string{"Ann", "Carl"}},
+			expectedIds: []int64{1, 3},
+		},
+		{
+			name:        "filter by range, must return rows within inclusive range",
+			filter:      UsersFilter{IdBetween: &[2]int64{2, 3}},
+			expectedIds: []int64{2, 3},
+		},
+		{
+			name:        "filter by NULL, must return rows with NULL value",
+			filter:      UsersFilter{LastNameIsNull: &isNull},
+			expectedIds: []int64{2},
+		},
+		{
+			name:        "filter by not NULL, must return rows with not NULL value",
+			filter:      UsersFilter{LastNameIsNull: &isNotNull},
+			expectedIds: []int64{1, 3},
+		},
+		{
+			name:        "several predicates, must return rows matching all predicates",
+			filter:      UsersFilter{FirstNameIn: []string{"Ann"}, EmailLike: &emailLike},
+			expectedIds: []int64{1},
+		},
+		{
+			name:        "order by columns, must return rows ordered by each column",
+			order:       []UsersOrder{{Column: UsersColumnFirstName}, {Column: UsersColumnId, IsDescending: true}},
+			expectedIds: []int64{3, 1, 2},
+		},
+		{
+			name:        "limit, must return first rows",
+			order:       []UsersOrder{{Column: UsersColumnEmail, IsDescending: true}},
+			limit:       2,
+			expectedIds: []int64{3, 2},
+		},
+		{
+			name:          "unknown order column, must return error",
+			order:         []UsersOrder{{Column: "unknown"}},
+			expectedError: "invalid order column \"unknown\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result, err := repository.FindBy(ctx, tt.filter, tt.order, tt.limit)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("FindBy() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("FindBy() returned error: %v", err)
+				}
+				if !reflect.DeepEqual(userIds(result), tt.expectedIds) {
+					t.Errorf("FindBy() returned rows %v, expected %v", userIds(result), tt.expectedIds)
+				}
+			},
+		)
+	}
+}
+
+func TestUsersRepositoryInMemory_FindPage(t *testing.T) {
+	repository := createUsersRepositoryInMemory(t, "first@example.com", "second@example.com", "third@example.com")
+
+	tests := []struct {
+		name          string
+		limit         int
+		offset        int
+		expectedIds   []int64
+		expectedError string
+	}{
+		{
+			name:        "limit and offset, must return page of rows ordered by primary key",
+			limit:       2,
+			offset:      1,
+			expectedIds: []int64{2, 3},
+		},
+		{
+			name:        "offset after last row, must return no rows",
+			limit:       2,
+			offset:      3,
+			expectedIds: nil,
+		},
+		{
+			name:          "zero limit, must return error",
+			limit:         0,
+			offset:        0,
+			expectedError: "limit must be positive, 0 given",
+		},
+		{
+			name:          "negative limit, must return error",
+			limit:         -1,
+			offset:        0,
+			expectedError: "limit must be positive, -1 given",
+		},
+		{
+			name:          "negative offset, must return error",
+			limit:         1,
+			offset:        -1,
+			expectedError: "offset must not be negative, -1 given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result, err := repository.FindPage(context.Background(), tt.limit, tt.offset)
+
+				if tt.expectedError != "" {
+					if err == nil || err.Error() != tt.expectedError {
+						t.Errorf("FindPage() must return error \"%s\", returned \"%v\"", tt.expectedError, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("FindPage() returned error: %v", err)
+				}
+				if !reflect.DeepEqual(userIds(result), tt.expectedIds) {
+					t.Errorf("FindPage() returned rows %v, expected %v", userIds(result), tt.expectedIds)
+				}
+			},
+		)
+	}
+}
+
+func TestUsersRepositoryInMemory_FindPageAfter(t *testing.T) {
+	ctx := context.Background()
+	repository := createUsersRepositoryInMemory(t, "first@example.com", "second@example.com", "third@example.com")
+
+	t.Run(
+		"pages by cursor, must return all rows and empty cursor of the last page", func(t *testing.T) {
+			var ids []int64
+			cursor := ""
+			for pagesCount := 0; pagesCount == 0 || cursor != ""; pagesCount++ {
+				if pagesCount > 3 {
+					t.Fatalf("FindPageAfter() must return empty cursor of the last page")
+				}
+
+				result, nextCursor, err := repository.FindPageAfter(ctx, cursor, 2)
+				if err != nil {
+					t.Fatalf("FindPageAfter() returned error: %v", err)
+				}
+
+				ids = append(ids, userIds(result)...)
+				cursor = nextCursor
+			}
+
+			if !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+				t.Errorf("FindPageAfter() returned rows %v, expected %v", ids, []int64{1, 2, 3})
+			}
+		},
+	)
+
+	t.Run(
+		"zero limit, must return error", func(t *testing.T) {
+			const expectedError = "limit must be positive, 0 given"
+
+			_, _, err := repository.FindPageAfter(ctx, "", 0)
+
+			if err == nil || err.Error() != expectedError {
+				t.Errorf("FindPageAfter() must return error \"%s\", returned \"%v\"", expectedError, err)
+			}
+		},
+	)
+}
+
+func TestUsersRepositoryInMemory_UpdateColumns(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"changed columns of model, must update only changed columns", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+			found, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			user := NewUsersFromDTO(found)
+			user.SetFirstName("First")
+			err = repository.Update(ctx, &UsersDTO{Id: 1, Email: "other@example.com", FirstName: found.FirstName})
+			if err != nil {
+				t.Fatalf("Update() returned error: %v", err)
+			}
+
+			dto := user.ToDTO()
+			err = repository.UpdateColumns(ctx, &dto, user.ChangedColumns())
+			if err != nil {
+				t.Fatalf("UpdateColumns() returned error: %v", err)
+			}
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			expected := UsersDTO{Id: 1, Email: "other@example.com", FirstName: "First"}
+			if result != expected {
+				t.Errorf("UpdateColumns() updated row to %v, expected %v", result, expected)
+			}
+		},
+	)
+
+	t.Run(
+		"no columns, must not update row", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+			err := repository.UpdateColumns(ctx, &UsersDTO{Id: 1, Email: "changed@example.com"}, nil)
+			if err != nil {
+				t.Fatalf("UpdateColumns() returned error: %v", err)
+			}
+
+			result, err := repository.FindById(ctx, 1)
+			if err != nil {
+				t.Fatalf("FindById() returned error: %v", err)
+			}
+			if result.Email != "first@example.com" {
+				t.Errorf("UpdateColumns() must not update row, email is \"%s\"", result.Email)
+			}
+		},
+	)
+
+	t.Run(
+		"primary key column, must return error", func(t *testing.T) {
+			const expectedError = "column \"id\" could not be updated"
+			repository := createUsersRepositoryInMemory(t, "first@example.com")
+
+			err := repository.UpdateColumns(ctx, &UsersDTO{Id: 1}, []string{"email", "id"})
+
+			if err == nil || err.Error() != expectedError {
+				t.Errorf("UpdateColumns() must return error \"%s\", returned \"%v\"", expectedError, err)
+			}
+		},
+	)
+
+	t.Run(
+		"changed email of other row, must return unique violation", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t, "first@example.com", "second@example.com")
+
+			err := repository.UpdateColumns(ctx, &UsersDTO{Id: 2, Email: "first@example.com"}, []string{"email"})
+
+			if !isUniqueViolation(err, "users_email_key") {
+				t.Errorf("UpdateColumns() must return unique violation of \"users_email_key\", returned \"%v\"", err)
+			}
+		},
+	)
+
+	t.Run(
+		"missing row, must return sql.ErrNoRows", func(t *testing.T) {
+			repository := createUsersRepositoryInMemory(t)
+
+			err := repository.UpdateColumns(ctx, &UsersDTO{Id: 1}, []string{"email"})
+
+			if !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("UpdateColumns() must return sql.ErrNoRows, returned \"%v\"", err)
+			}
+		},
+	)
+}
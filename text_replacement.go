@@ -0,0 +1,7 @@
+package gorep
+
+type textReplacement struct {
+	Start int
+	End   int
+	Text  string
+}